@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"text/template"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/ci"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var backfillLongDesc = `Walk the entire history of your repository and propose every semantic version that would
+have been released had nsv been used from the start. A release is cut at each boundary where
+an increment is detected, either any commit or only merge commits. Tags are only created when
+explicitly requested.
+
+Environment Variables:
+
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_BOUNDARY        | the commits where a release can be cut. The boundary can be    |
+|                     | one of either commit or merge (default: commit)                |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_CREATE          | create and push each proposed tag, rather than only printing   |
+|                     | them                                                           |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_FORMAT          | provide a go template for changing the default version format  |
+| NSV_IGNORE_AUTHORS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their author email                                  |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_INITIAL_VERSION | the version to release when no previous tag exists, rather     |
+|                     | than bumping from 0.0.0, e.g. 1.0.0                            |
+| NSV_MAJOR_PATTERN   | a regular expression for triggering a major semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MIN_VERSION     | a minimum version that the next version will be raised to if   |
+|                     | it would otherwise fall below it                               |
+| NSV_MINOR_PATTERN   | a regular expression for triggering a minor semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATCH_PATTERN   | a regular expression for triggering a patch semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_REF             | walk the history up to a given commit-ish, rather than HEAD    |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |
+| NSV_SINCE           | walk the history from a given tag, rather than the latest tag  |
+| NSV_TAG_MESSAGE     | a custom message for each annotated tag, supports go text      |
+|                     | templates. The default is: "chore: tagged release {{.Tag}}"    |
+| NSV_TAG_TYPE        | the type of tag to create. The type can be one of either       |
+|                     | annotated or lightweight (default: annotated)                  |
+| NSV_V_PREFIX        | prefix the first tag with a v, if it is the convention of the  |
+|                     | detected language, such as node, rust or terraform             |`
+
+func backfillCmd(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill [<path>...]",
+		Short: "Propose or create the missing tags from the history of a repository",
+		Long:  backfillLongDesc,
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			opts.Paths = defaultIfEmpty(args, []string{git.RelativeAtRoot})
+
+			if err := verifyTextTemplate(opts.TagMessage); err != nil {
+				return err
+			}
+
+			if err := nsv.CheckBoundary(opts.Boundary); err != nil {
+				return err
+			}
+
+			if !slices.Contains(tagTypes, opts.TagType) {
+				return UnsupportedTagTypeError{Type: opts.TagType}
+			}
+
+			return versionChecks(opts)
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gitc, err := git.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return doBackfill(nsv.NewGitRepository(gitc), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Boundary, "boundary", nsv.BoundaryCommit, "the commits where a release can be cut. The boundary "+
+		"can be one of either commit or merge")
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
+	flags.BoolVar(&opts.Create, "create", false, "create and push each proposed tag, rather than only printing them")
+	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
+	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
+	flags.StringSliceVar(&opts.IgnoreAuthors, "ignore-authors", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their author email")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.StringVar(&opts.InitialVersion, "initial-version", "", "the version to release when no previous tag exists, "+
+		"rather than bumping from 0.0.0, e.g. 1.0.0")
+	flags.StringVar(&opts.MajorPattern, "major-pattern", "", "a regular expression for triggering a major semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a major semantic version increment")
+	flags.StringVar(&opts.MinVersion, "min-version", "", "a minimum version that the next version will be raised to "+
+		"if it would otherwise fall below it")
+	flags.StringVar(&opts.MinorPattern, "minor-pattern", "", "a regular expression for triggering a minor semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a minor semantic version increment")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.PatchPattern, "patch-pattern", "", "a regular expression for triggering a patch semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a patch semantic version increment")
+	flags.StringVar(&opts.Ref, "ref", "", "walk the history up to a given commit-ish, rather than HEAD")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
+	flags.StringVar(&opts.Since, "since", "", "walk the history from a given tag, rather than the latest tag")
+	flags.StringVarP(&opts.TagMessage, "tag-message", "A", tagMessageTmpl, "a custom message for each annotated tag, supports go text templates")
+	flags.StringVar(&opts.TagType, "tag-type", annotatedTag, "the type of tag to create. The type can be one of either "+
+		"annotated or lightweight")
+	flags.BoolVar(&opts.VPrefix, "v-prefix", false, "prefix the first tag with a v, if it is the convention of the "+
+		"detected language, such as node, rust or terraform")
+
+	cmd.RegisterFlagCompletionFunc("boundary", boundaryFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("tag-type", tagTypeFlagShellComp)
+	return cmd
+}
+
+func boundaryFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return nsv.Boundaries, cobra.ShellCompDirectiveDefault
+}
+
+func doBackfill(repo nsv.Repository, opts *Options) error {
+	var vers []*nsv.Next
+	for _, path := range opts.Paths {
+		backfilled, err := nsv.Backfill(repo, opts.Boundary, nextOptions(opts, path))
+		if err != nil {
+			return err
+		}
+
+		vers = append(vers, backfilled...)
+	}
+
+	if len(vers) == 0 {
+		opts.Logger.Info("nothing to backfill for given paths", "paths", opts.Paths)
+		return nil
+	}
+
+	tui.PrintBackfill(vers, tui.BackfillOptions{Out: opts.Out})
+
+	if !opts.Create {
+		return nil
+	}
+
+	return createTags(repo, vers, opts)
+}
+
+func createTags(repo nsv.Repository, vers []*nsv.Next, opts *Options) error {
+	tmpl, _ := template.New("tag-template").Parse(opts.TagMessage)
+
+	existing, err := repo.Tags("")
+	if err != nil {
+		return err
+	}
+
+	var collisions []nsv.TagCollision
+	for _, ver := range vers {
+		if slices.Contains(existing, ver.Tag) {
+			collisions = append(collisions, nsv.TagCollision{Tag: ver.Tag, Local: true})
+		}
+	}
+
+	// Tags are checked upfront, preventing a partially backfilled history
+	if len(collisions) > 0 {
+		return nsv.TagCollisionError{Collisions: collisions}
+	}
+
+	tags := make([]string, 0, len(vers))
+	for _, ver := range vers {
+		var annotation string
+		if opts.TagType == annotatedTag {
+			var buf bytes.Buffer
+			tmpl.Execute(&buf, release{
+				Tag:             ver.Tag,
+				PrevTag:         ver.PrevTag,
+				SkipPipelineTag: ci.Detect().SkipPipelineTag,
+			})
+			annotation = buf.String()
+		}
+
+		if err := repo.Tag(ver.Tag, ver.Log[0].Hash, annotation); err != nil {
+			return err
+		}
+		opts.Logger.Info("tagged release with", "tag", ver.Tag, "type", opts.TagType, "hash", ver.Log[0].AbbrevHash)
+		tags = append(tags, ver.Tag)
+	}
+
+	if err := repo.Push(tags...); err != nil {
+		return err
+	}
+	opts.Logger.Info("pushed all backfilled tags to remote", "tags", len(tags))
+	return nil
+}
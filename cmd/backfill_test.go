@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfill(t *testing.T) {
+	log := `fix: search results not sorted by relevance
+docs: document search filters
+feat: support filtering of search results
+feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := backfillCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "0.1.0")
+	assert.Contains(t, buf.String(), "0.2.0")
+	assert.Contains(t, buf.String(), "0.2.1")
+	assert.Empty(t, gittest.Tags(t))
+}
+
+func TestBackfillCreate(t *testing.T) {
+	log := `fix: search results not sorted by relevance
+feat: support filtering of search results
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	cmd := backfillCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--create"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"0.1.0", "0.2.0", "0.2.1"}, gittest.Tags(t))
+	remoteTags := gittest.RemoteTags(t)
+	assert.Contains(t, remoteTags, "0.2.0")
+	assert.Contains(t, remoteTags, "0.2.1")
+
+	logs := gittest.Log(t)
+	assert.Contains(t, gittest.Show(t, "0.2.1"), logs[0].Hash)
+	assert.Contains(t, gittest.Show(t, "0.2.0"), logs[1].Hash)
+	assert.Contains(t, gittest.Show(t, "0.2.0"), "chore: tagged release 0.2.0")
+}
+
+func TestBackfillUnsupportedBoundary(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := backfillCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--boundary", "release"})
+	err := cmd.Execute()
+	require.EqualError(t, err, "boundary 'release' is not supported, must be one of either: commit, merge")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var diffLongDesc = `Compare two refs, listing all commits grouped by their conventional commit type, any
+breaking changes, and every file changed within a path. By default, the latest release
+is compared against the release before it.
+
+Environment Variables:
+
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_FORMAT          | provide a go template for changing the default version format  |
+| NSV_IGNORE_AUTHORS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their author email                                  |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATH            | the path to compare, which also determines the prefix of its   |
+|                     | release tags within a monorepo                                 |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |`
+
+func diffCmd(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [<from>] [<to>]",
+		Short: "List all changes between two releases",
+		Long:  diffLongDesc,
+		Args:  cobra.MaximumNArgs(2),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			opts.Paths = []string{opts.Path}
+
+			return versionChecks(opts)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			gitc, err := git.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var from, to string
+			if len(args) > 0 {
+				from = args[0]
+			}
+			if len(args) > 1 {
+				to = args[1]
+			}
+
+			return doDiff(nsv.NewGitRepository(gitc), from, to, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
+	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
+	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
+	flags.StringSliceVar(&opts.IgnoreAuthors, "ignore-authors", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their author email")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.Path, "path", git.RelativeAtRoot, "the path to compare, which also determines the prefix of its "+
+		"release tags within a monorepo")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
+
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
+	return cmd
+}
+
+func doDiff(repo nsv.Repository, from, to string, opts *Options) error {
+	cmp, err := nsv.Compare(repo, from, to, nextOptions(opts, opts.Path))
+	if err != nil {
+		return err
+	}
+
+	tui.PrintComparison(cmp, tui.CompareOptions{Out: opts.Out})
+	return nil
+}
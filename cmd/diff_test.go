@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	log := `fix: pagination off by one
+(tag: 0.2.0) feat!: drop support for the v1 search api
+fix: search results not sorted by relevance
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := diffCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "Breaking Changes")
+	assert.Contains(t, out, "feat!: drop support for the v1 search api")
+	assert.Contains(t, out, "fix: search results not sorted by relevance")
+	assert.NotContains(t, out, "fix: pagination off by one")
+}
+
+func TestDiffWithRefs(t *testing.T) {
+	log := `fix: pagination off by one
+(tag: 0.2.0) feat: support filtering of search results
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := diffCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"0.2.0", "HEAD"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "fix: pagination off by one")
+	assert.NotContains(t, out, "feat: support filtering of search results")
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+type BranchNotAllowedError struct {
+	Branch  string
+	Allowed []string
+}
+
+func (e BranchNotAllowedError) Error() string {
+	branch := e.Branch
+	if branch == "" {
+		branch = git.HeadRef
+	}
+
+	return fmt.Sprintf("releasing from branch '%s' is not allowed, must be one of either: %s",
+		branch, strings.Join(e.Allowed, ", "))
+}
+
+type DirtyWorkingTreeError struct {
+	Paths []string
+}
+
+func (e DirtyWorkingTreeError) Error() string {
+	return "releasing from a dirty working tree is not allowed, uncommitted changes to: " + strings.Join(e.Paths, ", ")
+}
+
+type RemoteMismatchError struct {
+	Branch string
+	Local  string
+	Remote string
+}
+
+func (e RemoteMismatchError) Error() string {
+	if e.Remote == "" {
+		return fmt.Sprintf("releasing is not allowed as branch '%s' does not exist on the remote", e.Branch)
+	}
+
+	return fmt.Sprintf("releasing is not allowed as HEAD (%s) does not match the tip of remote branch '%s' (%s)",
+		abbrevHash(e.Local), e.Branch, abbrevHash(e.Remote))
+}
+
+type AuthorNotAllowedError struct {
+	Author  string
+	Allowed []string
+}
+
+func (e AuthorNotAllowedError) Error() string {
+	return fmt.Sprintf("releasing a commit authored by '%s' is not allowed, must be one of either: %s",
+		e.Author, strings.Join(e.Allowed, ", "))
+}
+
+func abbrevHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// gateRelease ensures a release can only happen from an allowed branch, with a clean
+// working tree and a HEAD that matches the tip of the remote branch. Gating is only
+// enabled when a list of allowed branches has been provided
+func gateRelease(gitc *git.Client, opts *Options) error {
+	if len(opts.AllowBranches) == 0 {
+		return nil
+	}
+
+	_, branch, err := resolveBranch(gitc, opts)
+	if err != nil {
+		return err
+	}
+
+	if !branchAllowed(branch, opts.AllowBranches) {
+		return BranchNotAllowedError{Branch: branch, Allowed: opts.AllowBranches}
+	}
+
+	statuses, err := gitc.PorcelainStatus(git.WithIgnoreUntracked())
+	if err != nil {
+		return err
+	}
+
+	if len(statuses) > 0 {
+		paths := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			paths = append(paths, status.Path)
+		}
+		return DirtyWorkingTreeError{Paths: paths}
+	}
+
+	local, err := gitc.Exec("git rev-parse " + git.HeadRef)
+	if err != nil {
+		return err
+	}
+
+	remote, err := gitc.Exec("git ls-remote --heads origin refs/heads/" + branch)
+	if err != nil {
+		return err
+	}
+	remote, _, _ = strings.Cut(remote, "\t")
+
+	if local != remote {
+		return RemoteMismatchError{Branch: branch, Local: local, Remote: remote}
+	}
+
+	opts.Logger.Debug("release gating checks passed", "branch", branch, "hash", abbrevHash(local))
+	return nil
+}
+
+func branchAllowed(branch string, allowed []string) bool {
+	if branch == "" {
+		return false
+	}
+
+	for _, pattern := range allowed {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gateAuthors gates the author of every path before any is released, avoiding a partial
+// release where earlier paths are tagged before a later one is rejected. Versions are
+// calculated without patching any files, leaving the working tree untouched
+func gateAuthors(gitc *git.Client, opts *Options) error {
+	if len(opts.AllowAuthors) == 0 {
+		return nil
+	}
+
+	for _, path := range opts.Paths {
+		nextVersionOpts := nextOptions(opts, path)
+		nextVersionOpts.Logger = nsv.NoopLogger{}
+
+		next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nextVersionOpts)
+		if err != nil {
+			return err
+		}
+
+		if next == nil {
+			continue
+		}
+
+		if err := gateAuthor(gitc, next, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gateAuthor ensures the commit that triggered the next semantic version was authored
+// by an allowed user, identified by either their name or email. Gating is only enabled
+// when a list of allowed authors has been provided
+func gateAuthor(gitc *git.Client, next *nsv.Next, opts *Options) error {
+	if len(opts.AllowAuthors) == 0 {
+		return nil
+	}
+
+	hash := triggerHash(next)
+	commits, err := gitc.ShowCommits(hash)
+	if err != nil {
+		return err
+	}
+
+	author := commits[hash].Author
+	for _, allowed := range opts.AllowAuthors {
+		if strings.EqualFold(allowed, author.Email) || allowed == author.Name {
+			return nil
+		}
+	}
+
+	return AuthorNotAllowedError{Author: author.Email, Allowed: opts.AllowAuthors}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var outputFormats = []string{outputTable, outputJSON}
+
+type UnsupportedOutputError struct {
+	Output string
+}
+
+func (e UnsupportedOutputError) Error() string {
+	return fmt.Sprintf("output '%s' is not supported, must be one of either: %s",
+		e.Output, strings.Join(outputFormats, ", "))
+}
+
+var historyLongDesc = `List every release of a path in semantic version order, showing when and by whom it
+was tagged, the number of commits it contained and its increment. The message of an
+annotated tag is also included.
+
+Environment Variables:
+
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_OUTPUT          | the format used to list all releases. The format can be one of |
+|                     | either table or json (default: table)                          |
+| NSV_REF             | only list releases reachable from a given commit-ish, rather   |
+|                     | than HEAD                                                      |`
+
+type historyRelease struct {
+	Tag       string    `json:"tag"`
+	PrevTag   string    `json:"prev_tag,omitempty"`
+	Hash      string    `json:"hash"`
+	Date      time.Time `json:"date"`
+	Commits   int       `json:"commits"`
+	Increment string    `json:"increment"`
+	Tagger    string    `json:"tagger"`
+	Message   string    `json:"message,omitempty"`
+}
+
+func historyCmd(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [<path>]",
+		Short: "List every release of a path",
+		Long:  historyLongDesc,
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			opts.Paths = defaultIfEmpty(args, []string{git.RelativeAtRoot})
+
+			if !slices.Contains(outputFormats, opts.Output) {
+				return UnsupportedOutputError{Output: opts.Output}
+			}
+
+			return pathsExist(opts.Paths)
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gitc, err := git.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return doHistory(nsv.NewGitRepository(gitc), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
+	flags.StringVarP(&opts.Output, "output", "o", outputTable, "the format used to list all releases. The format can be "+
+		"one of either table or json")
+	flags.StringVar(&opts.Ref, "ref", "", "only list releases reachable from a given commit-ish, rather than HEAD")
+
+	cmd.RegisterFlagCompletionFunc("output", outputFlagShellComp)
+	return cmd
+}
+
+func outputFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return outputFormats, cobra.ShellCompDirectiveDefault
+}
+
+func doHistory(repo nsv.Repository, opts *Options) error {
+	releases, err := nsv.History(repo, nsv.Options{
+		FixShallow: opts.FixShallow,
+		Logger:     opts.Logger,
+		Path:       opts.Paths[0],
+		Ref:        opts.Ref,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == outputJSON {
+		out := make([]historyRelease, 0, len(releases))
+		for _, rel := range releases {
+			out = append(out, historyRelease{
+				Tag:       rel.Tag,
+				PrevTag:   rel.PrevTag,
+				Hash:      rel.Hash,
+				Date:      rel.Date,
+				Commits:   rel.Commits,
+				Increment: rel.Increment.String(),
+				Tagger:    rel.Tagger,
+				Message:   rel.Message,
+			})
+		}
+
+		enc := json.NewEncoder(opts.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(releases) == 0 {
+		opts.Logger.Info("no releases found for path", "path", opts.Paths[0])
+		return nil
+	}
+
+	tui.PrintHistory(releases, tui.HistoryOptions{Out: opts.Out})
+	return nil
+}
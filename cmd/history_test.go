@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	log := `(tag: 0.2.0) feat: support filtering of search results
+fix: search results not sorted by relevance
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := historyCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "0.1.0")
+	assert.Contains(t, buf.String(), "0.2.0")
+}
+
+func TestHistoryJSON(t *testing.T) {
+	log := `(tag: 0.2.0) feat: support filtering of search results
+fix: search results not sorted by relevance
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := historyCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--output", "json"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	var releases []historyRelease
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &releases))
+	require.Len(t, releases, 2)
+
+	assert.Equal(t, "0.2.0", releases[1].Tag)
+	assert.Equal(t, "0.1.0", releases[1].PrevTag)
+	assert.Equal(t, 2, releases[1].Commits)
+	assert.Equal(t, "minor", releases[1].Increment)
+}
+
+func TestHistoryUnsupportedOutput(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := historyCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--output", "yaml"})
+	err := cmd.Execute()
+	require.EqualError(t, err, "output 'yaml' is not supported, must be one of either: table, json")
+}
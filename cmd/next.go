@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/ci"
 	"github.com/purpleclay/nsv/internal/nsv"
 	"github.com/purpleclay/nsv/internal/tui"
 	"github.com/spf13/cobra"
@@ -19,6 +22,11 @@ func (e MissingPathsError) Error() string {
 	return "paths do not exist within the current repository: " + strings.Join(e.Paths, ", ")
 }
 
+var (
+	errUnresolvedPRBase = errors.New("unable to resolve the base branch of the pull request, please provide one using --pr-base")
+	errRegexNoPatterns  = errors.New("the regex convention requires at least one of --major-pattern, --minor-pattern or --patch-pattern")
+)
+
 type InvalidPrettyFormatError struct {
 	Format string
 }
@@ -28,27 +36,83 @@ func (e InvalidPrettyFormatError) Error() string {
 		e.Format, strings.Join(tui.PrettyFormats, ", "))
 }
 
+type InvalidSummaryFormatError struct {
+	Format string
+}
+
+func (e InvalidSummaryFormatError) Error() string {
+	return fmt.Sprintf("summary format '%s' is not supported, must be one of either: %s",
+		e.Format, strings.Join(tui.Formats, ", "))
+}
+
 var nextLongDesc = `Generate the next semantic version based on the conventional commit history of your repository.
 
 Environment Variables:
 
-| Name               | Description                                                    |
-|--------------------|----------------------------------------------------------------|
-| LOG_LEVEL          | the level of logging when printing to stderr (default: info)   |
-| NO_COLOR           | switch to using an ASCII color profile within the terminal     |
-| NO_LOG             | disable all log output                                         |
-| NSV_FIX_SHALLOW    | fix a shallow clone of a repository if detected                |
-| NSV_FORMAT         | provide a go template for changing the default version format  |
-| NSV_MAJOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a major semantic version increment                  |
-| NSV_MINOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a minor semantic version increment                  |
-| NSV_PATCH_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a patch semantic version increment                  |
-| NSV_PRETTY         | pretty-print the output of the next semantic version in a      |
-|                    | given format. The format can be one of either full or compact. |
-|                    | Must be used in conjunction with NSV_SHOW (default: full)      |
-| NSV_SHOW           | show how the next semantic version was generated               |`
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| COLUMNS             | the width of the terminal when printing a summary, detected    |
+|                     | automatically if not set                                       |
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_FORMAT          | provide a go template for changing the default version format  |
+| NSV_IGNORE_AUTHORS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their author email                                  |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_INITIAL_VERSION | the version to release when no previous tag exists, rather     |
+|                     | than bumping from 0.0.0, e.g. 1.0.0                            |
+| NSV_MAJOR_PATTERN   | a regular expression for triggering a major semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MAX_LINES       | the maximum number of lines of each commit message to show     |
+|                     | within a summary. Messages are never truncated if set to 0     |
+| NSV_MIN_VERSION     | a minimum version that the next version will be raised to if   |
+|                     | it would otherwise fall below it                               |
+| NSV_MINOR_PATTERN   | a regular expression for triggering a minor semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_NO_CI_OUTPUT    | disable writing outputs native to the detected CI platform,    |
+|                     | such as GitHub step outputs or a GitLab dotenv file            |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATCH_PATTERN   | a regular expression for triggering a patch semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_PR_BASE         | the branch a pull request will be merged into when previewing  |
+|                     | a release. If not set, it will be resolved from the CI         |
+|                     | environment or the default branch of the repository            |
+| NSV_PR_PREVIEW      | preview the release of a pull request as markdown, using only  |
+|                     | the commits within the pull request                            |
+| NSV_PR_PREVIEW_OUT  | write the markdown preview of a pull request to a file rather  |
+|                     | than stdout                                                    |
+| NSV_PRETTY          | pretty-print the output of the next semantic version in a      |
+|                     | given format. The format can be one of either full, compact or |
+|                     | oneline. Must be used in conjunction with NSV_SHOW             |
+|                     | (default: full)                                                |
+| NSV_REF             | calculate the next semantic version at a given commit-ish,     |
+|                     | rather than HEAD. Only tags reachable from it are considered   |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |
+| NSV_SHOW            | show how the next semantic version was generated               |
+| NSV_SINCE           | calculate the next semantic version from a given tag, rather   |
+|                     | than the latest tag                                            |
+| NSV_SUMMARY_FORMAT  | the format to render the summary in. The format can be one of  |
+|                     | either terminal, markdown or html. Must be used in conjunction |
+|                     | with NSV_SHOW (default: terminal)                              |
+| NSV_V_PREFIX        | prefix the first tag with a v, if it is the convention of the  |
+|                     | detected language, such as node, rust or terraform             |`
 
 func nextCmd(opts *Options) *cobra.Command {
 	cmd := &cobra.Command{
@@ -71,18 +135,58 @@ func nextCmd(opts *Options) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
 	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
 	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
+	flags.StringSliceVar(&opts.IgnoreAuthors, "ignore-authors", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their author email")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.StringVar(&opts.InitialVersion, "initial-version", "", "the version to release when no previous tag exists, "+
+		"rather than bumping from 0.0.0, e.g. 1.0.0")
+	flags.StringVar(&opts.MajorPattern, "major-pattern", "", "a regular expression for triggering a major semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a major semantic version increment")
+	flags.IntVar(&opts.MaxLines, "max-lines", 0, "the maximum number of lines of each commit message to show within a summary. "+
+		"Messages are never truncated if set to 0")
+	flags.StringVar(&opts.MinVersion, "min-version", "", "a minimum version that the next version will be raised to "+
+		"if it would otherwise fall below it")
+	flags.StringVar(&opts.MinorPattern, "minor-pattern", "", "a regular expression for triggering a minor semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a minor semantic version increment")
+	flags.BoolVar(&opts.NoCIOutput, "no-ci-output", false, "disable writing outputs native to the detected CI platform, "+
+		"such as GitHub step outputs or a GitLab dotenv file")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.PatchPattern, "patch-pattern", "", "a regular expression for triggering a patch semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a patch semantic version increment")
+	flags.StringVar(&opts.PRBase, "pr-base", "", "the branch a pull request will be merged into when previewing a release. "+
+		"If not set, it will be resolved from the CI environment or the default branch of the repository")
+	flags.BoolVar(&opts.PRPreview, "pr-preview", false, "preview the release of a pull request as markdown, using only the "+
+		"commits within the pull request")
+	flags.StringVar(&opts.PRPreviewOut, "pr-preview-out", "", "write the markdown preview of a pull request to a file rather than stdout")
 	flags.StringVarP(&opts.Pretty, "pretty", "p", string(tui.Full), "pretty-print the output of the next semantic version in a given format. "+
-		"The format can be one of either full or compact. Must be used in conjunction with --show")
+		"The format can be one of either full, compact or oneline. Must be used in conjunction with --show")
+	flags.StringVar(&opts.Ref, "ref", "", "calculate the next semantic version at a given commit-ish, rather than HEAD. "+
+		"Only tags reachable from it are considered")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
 	flags.BoolVarP(&opts.Show, "show", "s", false, "show how the next semantic version was generated")
+	flags.StringVar(&opts.Since, "since", "", "calculate the next semantic version from a given tag, rather than the latest tag")
+	flags.StringVar(&opts.SummaryFormat, "summary-format", string(tui.Terminal), "the format to render the summary in. The format "+
+		"can be one of either terminal, markdown or html. Must be used in conjunction with --show")
+	flags.BoolVar(&opts.VPrefix, "v-prefix", false, "prefix the first tag with a v, if it is the convention of the "+
+		"detected language, such as node, rust or terraform")
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
 	cmd.RegisterFlagCompletionFunc("pretty", prettyFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("summary-format", summaryFormatFlagShellComp)
 	return cmd
 }
 
@@ -90,18 +194,91 @@ func prettyFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobr
 	return tui.PrettyFormats, cobra.ShellCompDirectiveDefault
 }
 
+func summaryFormatFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return tui.Formats, cobra.ShellCompDirectiveDefault
+}
+
+func goModuleFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return nsv.GoModuleStrategies, cobra.ShellCompDirectiveDefault
+}
+
+func conventionFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return nsv.Conventions, cobra.ShellCompDirectiveDefault
+}
+
 func preRunChecks(opts *Options) error {
 	if err := supportedPrettyFormat(opts.Pretty); err != nil {
 		return err
 	}
 
+	if !slices.Contains(tui.Formats, opts.SummaryFormat) {
+		return InvalidSummaryFormatError{Format: opts.SummaryFormat}
+	}
+
+	return versionChecks(opts)
+}
+
+// versionChecks validates all options that influence how the next semantic version
+// is calculated, and that every path exists
+func versionChecks(opts *Options) error {
 	if err := nsv.CheckTemplate(opts.VersionFormat); err != nil {
 		return err
 	}
 
+	if _, err := nsv.NewConvention(nextOptions(opts, "")); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(opts.Convention, nsv.RegexConvention) &&
+		opts.MajorPattern == "" && opts.MinorPattern == "" && opts.PatchPattern == "" {
+		return errRegexNoPatterns
+	}
+
+	if err := nsv.CheckVersion("initial version", opts.InitialVersion); err != nil {
+		return err
+	}
+
+	if err := nsv.CheckVersion("min version", opts.MinVersion); err != nil {
+		return err
+	}
+
+	if err := nsv.CheckGoModuleStrategy(opts.GoModule); err != nil {
+		return err
+	}
+
+	if _, err := nsv.NewIgnoreRules(opts.IgnoreCommits, opts.IgnoreAuthors, opts.NoIgnores); err != nil {
+		return err
+	}
+
 	return pathsExist(opts.Paths)
 }
 
+func nextOptions(opts *Options, path string) nsv.Options {
+	return nsv.Options{
+		Convention:       opts.Convention,
+		FixShallow:       opts.FixShallow,
+		IgnoreAuthors:    opts.IgnoreAuthors,
+		IgnoreCommits:    opts.IgnoreCommits,
+		InitialVersion:   opts.InitialVersion,
+		Logger:           opts.Logger,
+		MajorPattern:     opts.MajorPattern,
+		MajorPrefixes:    opts.MajorPrefixes,
+		MinorPattern:     opts.MinorPattern,
+		MinorPrefixes:    opts.MinorPrefixes,
+		MinVersion:       opts.MinVersion,
+		NoDefaultIgnores: opts.NoIgnores,
+		ParseBody:        opts.ParseBody,
+		PatchPattern:     opts.PatchPattern,
+		PatchPrefixes:    opts.PatchPrefixes,
+		Path:             path,
+		Ref:              opts.Ref,
+		Rules:            opts.Rules,
+		Since:            opts.Since,
+		VersionFormat:    opts.VersionFormat,
+		VPrefix:          opts.VPrefix,
+	}
+}
+
 func supportedPrettyFormat(format string) error {
 	for _, p := range tui.PrettyFormats {
 		if p == format {
@@ -135,17 +312,20 @@ func pathsExist(paths []string) error {
 }
 
 func doNext(gitc *git.Client, opts *Options) error {
+	var baseRef string
+	if opts.PRPreview {
+		var err error
+		if baseRef, err = previewBaseRef(gitc, opts); err != nil {
+			return err
+		}
+	}
+
 	var vers []*nsv.Next
 	for _, path := range opts.Paths {
-		next, err := nsv.NextVersion(gitc, nsv.Options{
-			FixShallow:    opts.FixShallow,
-			MajorPrefixes: opts.MajorPrefixes,
-			MinorPrefixes: opts.MinorPrefixes,
-			Logger:        opts.Logger,
-			PatchPrefixes: opts.PatchPrefixes,
-			Path:          path,
-			VersionFormat: opts.VersionFormat,
-		})
+		nextVersionOpts := nextOptions(opts, path)
+		nextVersionOpts.BaseRef = baseRef
+
+		next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nextVersionOpts)
 		if err != nil {
 			return err
 		}
@@ -155,12 +335,65 @@ func doNext(gitc *git.Client, opts *Options) error {
 		}
 	}
 
+	if opts.PRPreview {
+		return writePreview(vers, opts)
+	}
+
 	if len(vers) == 0 {
 		opts.Logger.Info("nothing to release for given paths", "paths", opts.Paths)
 		return nil
 	}
 
 	printNext(vers, opts)
+	return writeCIOutputs(vers, opts)
+}
+
+func previewBaseRef(gitc *git.Client, opts *Options) (string, error) {
+	base := opts.PRBase
+	if base == "" {
+		ciEnv := ci.Detect()
+		base = ciEnv.TargetBranch
+		if base == "" {
+			base = ciEnv.DefaultBranch
+		}
+	}
+
+	if base == "" {
+		repo, err := gitc.Repository()
+		if err != nil {
+			return "", err
+		}
+		base = repo.DefaultBranch
+	}
+
+	if base == "" {
+		return "", errUnresolvedPRBase
+	}
+
+	// Prefer the remote tracking branch, as the local branch may not exist or be out of date
+	mergeBase, err := gitc.Exec(fmt.Sprintf("git merge-base %s origin/%s", git.HeadRef, base))
+	if err != nil {
+		if mergeBase, err = gitc.Exec(fmt.Sprintf("git merge-base %s %s", git.HeadRef, base)); err != nil {
+			return "", err
+		}
+	}
+
+	opts.Logger.Info("previewing pull request against base branch", "base", base, "merge_base", mergeBase)
+	return mergeBase, nil
+}
+
+func writePreview(vers []*nsv.Next, opts *Options) error {
+	out := opts.Out
+	if opts.PRPreviewOut != "" {
+		f, err := os.Create(opts.PRPreviewOut)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	tui.PrintPreview(vers, tui.PreviewOptions{Out: out})
 	return nil
 }
 
@@ -177,10 +410,39 @@ func printNext(vers []*nsv.Next, opts *Options) {
 	fmt.Fprint(opts.Out, strings.Join(tags, ","))
 
 	if opts.Show {
+		// Most CI platforms do not attach a terminal, but can provide its width
+		width := opts.Columns
+		if width == 0 {
+			width = tui.TerminalWidth(opts.Err)
+		}
+
 		tui.PrintSummary(vers, tui.SummaryOptions{
-			NoColor: opts.NoColor,
-			Out:     opts.Err,
-			Pretty:  tui.Pretty(opts.Pretty),
+			Format:   tui.Format(opts.SummaryFormat),
+			MaxLines: opts.MaxLines,
+			NoColor:  opts.NoColor,
+			Out:      opts.Err,
+			Pretty:   tui.Pretty(opts.Pretty),
+			Width:    width,
 		})
 	}
 }
+
+func writeCIOutputs(vers []*nsv.Next, opts *Options) error {
+	ciEnv := ci.Detect()
+	if opts.NoCIOutput || !ciEnv.Detected() {
+		return nil
+	}
+
+	outs := make([]ci.Output, 0, len(vers))
+	for _, ver := range vers {
+		outs = append(outs, ci.Output{
+			Path:      ver.LogDir,
+			Tag:       ver.Tag,
+			PrevTag:   ver.PrevTag,
+			Increment: nsv.AppliedIncrement(ver).String(),
+		})
+	}
+
+	opts.Logger.Debug("writing outputs for ci platform", "ci", ciEnv.Platform)
+	return ci.WriteOutputs(ciEnv, outs)
+}
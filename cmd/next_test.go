@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/purpleclay/gitz/gittest"
 	"github.com/stretchr/testify/assert"
@@ -25,3 +27,113 @@ feat: support pagination of search results`
 	require.NoError(t, err)
 	assert.Equal(t, "0.1.0", buf.String())
 }
+
+func TestNextPRPreview(t *testing.T) {
+	log := `(main, origin/main) feat: support pagination of search results
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.MustExec(t, "git checkout -b fix/search")
+	gittest.TempFile(t, "search.go", "package search")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "fix: search results are not sorted by relevance")
+
+	var buf bytes.Buffer
+	cmd := nextCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--pr-preview", "--pr-base", "main"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "| `.` | `0.1.1` | `0.1.0` | patch |")
+	assert.NotContains(t, buf.String(), "pagination")
+}
+
+func TestNextWithRules(t *testing.T) {
+	log := `(main, origin/main) feat(internal): cache compiled templates
+(tag: 0.1.0) feat: support pagination of search results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := nextCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--rules", "feat(internal)=patch"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.1", buf.String())
+}
+
+func TestNextWithInitialVersion(t *testing.T) {
+	log := "(main, origin/main) feat: support pagination of search results"
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := nextCmd(&Options{Out: &buf, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--initial-version", "1.0.0"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", buf.String())
+}
+
+func TestNextInvalidMinVersion(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := nextCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--min-version", "1.0"})
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "min version '1.0' is not a valid semantic version")
+}
+
+func TestNextShowMarkdownSummary(t *testing.T) {
+	log := `(main, origin/main) feat: support pagination of search results
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := nextCmd(&Options{Out: io.Discard, Err: &buf, Logger: noopLogger, NoLog: true})
+	cmd.SetArgs([]string{"--show", "--summary-format", "markdown"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "### 0.2.0 ↑↑ 0.1.0")
+	assert.Contains(t, buf.String(), "**feat**: support pagination of search results")
+}
+
+func TestNextRegexWithoutPatterns(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := nextCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--convention", "regex"})
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errRegexNoPatterns)
+}
+
+func TestNextInvalidSummaryFormat(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := nextCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--summary-format", "pdf"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "summary format 'pdf' is not supported, must be one of either: terminal, markdown, html")
+}
+
+func TestNextShowSummaryFitsColumns(t *testing.T) {
+	log := `(main, origin/main) feat: support pagination of search results with a configurable page size and cursor
+(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	var buf bytes.Buffer
+	cmd := nextCmd(&Options{Out: io.Discard, Err: &buf, Logger: noopLogger, NoLog: true, NoColor: true, Columns: 60})
+	cmd.SetArgs([]string{"--show", "--pretty", "oneline"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 60)
+	}
+	assert.Contains(t, buf.String(), "…")
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"text/template"
 
-	"github.com/purpleclay/chomp"
 	git "github.com/purpleclay/gitz"
 	"github.com/purpleclay/nsv/internal/ci"
 	"github.com/purpleclay/nsv/internal/nsv"
@@ -23,29 +22,71 @@ history of your repository.
 
 Environment Variables:
 
-| Name               | Description                                                    |
-|--------------------|----------------------------------------------------------------|
-| LOG_LEVEL          | the level of logging when printing to stderr (default: info)   |
-| NO_COLOR           | switch to using an ASCII color profile within the terminal     |
-| NO_LOG             | disable all log output                                         |
-| NSV_COMMIT_MESSAGE | a custom message when committing file changes, supports go     |
-|                    | text templates. The default is: "chore: patched files for      |
-|                    | release {{.Tag}} {{.SkipPipelineTag}}"                         |
-| NSV_DRY_RUN        | no changes will be made to the repository                      |
-| NSV_FIX_SHALLOW    | fix a shallow clone of a repository if detected                |
-| NSV_FORMAT         | provide a go template for changing the default version format  |
-| NSV_HOOK           | a user-defined hook that will be executed before any file      |
-|                    | changes are committed with the next semantic version           |
-| NSV_MAJOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a major semantic version increment                  |
-| NSV_MINOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a minor semantic version increment                  |
-| NSV_PATCH_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a patch semantic version increment                  |
-| NSV_PRETTY         | pretty-print the output of the next semantic version in a      |
-|                    | given format. The format can be one of either full or compact. |
-|                    | Must be used in conjunction with NSV_SHOW (default: full)      |
-| NSV_SHOW           | show how the next semantic version was generated               |
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| COLUMNS             | the width of the terminal when printing a summary, detected    |
+|                     | automatically if not set                                       |
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_BRANCH          | the branch to push changes to when the repository has a        |
+|                     | detached HEAD. If not set, it will be resolved from the CI     |
+|                     | environment, unless building a pull request                    |
+| NSV_COMMIT_MESSAGE  | a custom message when committing file changes, supports go     |
+|                     | text templates. The default is: "chore: patched files for      |
+|                     | release {{.Tag}} {{.SkipPipelineTag}}"                         |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_DRY_RUN         | no changes will be made to the repository                      |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_FORMAT          | provide a go template for changing the default version format  |
+| NSV_GO_MODULE       | the strategy to apply when a go module path does not match the |
+|                     | major version of the next tag. The strategy can be one of      |
+|                     | either warn, fail or patch. If not set, no check is made       |
+| NSV_HOOK            | a user-defined hook that will be executed before any file      |
+|                     | changes are committed with the next semantic version           |
+| NSV_IGNORE_AUTHORS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their author email                                  |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_INITIAL_VERSION | the version to release when no previous tag exists, rather     |
+|                     | than bumping from 0.0.0, e.g. 1.0.0                            |
+| NSV_MAJOR_PATTERN   | a regular expression for triggering a major semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MAX_LINES       | the maximum number of lines of each commit message to show     |
+|                     | within a summary. Messages are never truncated if set to 0     |
+| NSV_MIN_VERSION     | a minimum version that the next version will be raised to if   |
+|                     | it would otherwise fall below it                               |
+| NSV_MINOR_PATTERN   | a regular expression for triggering a minor semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_NO_CI_OUTPUT    | disable writing outputs native to the detected CI platform,    |
+|                     | such as GitHub step outputs or a GitLab dotenv file            |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATCH_PATTERN   | a regular expression for triggering a patch semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_PRETTY          | pretty-print the output of the next semantic version in a      |
+|                     | given format. The format can be one of either full, compact or |
+|                     | oneline. Must be used in conjunction with NSV_SHOW             |
+|                     | (default: full)                                                |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |
+| NSV_SHOW            | show how the next semantic version was generated               |
+| NSV_SUMMARY_FORMAT  | the format to render the summary in. The format can be one of  |
+|                     | either terminal, markdown or html. Must be used in conjunction |
+|                     | with NSV_SHOW (default: terminal)                              |
+| NSV_V_PREFIX        | prefix the first tag with a v, if it is the convention of the  |
+|                     | detected language, such as node, rust or terraform             |
 
 Hook Environment Variables:
 
@@ -93,23 +134,61 @@ func patchCmd(opts *Options) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
+	flags.StringVar(&opts.Branch, "branch", "", "the branch to push changes to when the repository has a detached HEAD. "+
+		"If not set, it will be resolved from the CI environment, unless building a pull request")
 	flags.StringVarP(&opts.CommitMessage, "commit-message", "M", commitMessageTmpl, "a custom message when committing file "+
 		"changes, supports go text templates")
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
 	flags.BoolVar(&opts.DryRun, "dry-run", false, "no changes will be made to the repository")
 	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
+	flags.StringVar(&opts.GoModule, "go-module", "", "the strategy to apply when a go module path does not match "+
+		"the major version of the next tag. The strategy can be one of either warn, fail or patch. If not set, no check is made")
 	flags.StringVar(&opts.Hook, "hook", "", "a user-defined hook that will be executed before any file changes are committed "+
 		"with the next semantic version")
 	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
+	flags.StringSliceVar(&opts.IgnoreAuthors, "ignore-authors", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their author email")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.StringVar(&opts.InitialVersion, "initial-version", "", "the version to release when no previous tag exists, "+
+		"rather than bumping from 0.0.0, e.g. 1.0.0")
+	flags.StringVar(&opts.MajorPattern, "major-pattern", "", "a regular expression for triggering a major semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a major semantic version increment")
+	flags.IntVar(&opts.MaxLines, "max-lines", 0, "the maximum number of lines of each commit message to show within a summary. "+
+		"Messages are never truncated if set to 0")
+	flags.StringVar(&opts.MinVersion, "min-version", "", "a minimum version that the next version will be raised to "+
+		"if it would otherwise fall below it")
+	flags.StringVar(&opts.MinorPattern, "minor-pattern", "", "a regular expression for triggering a minor semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a minor semantic version increment")
+	flags.BoolVar(&opts.NoCIOutput, "no-ci-output", false, "disable writing outputs native to the detected CI platform, "+
+		"such as GitHub step outputs or a GitLab dotenv file")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.PatchPattern, "patch-pattern", "", "a regular expression for triggering a patch semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a patch semantic version increment")
 	flags.StringVarP(&opts.Pretty, "pretty", "p", string(tui.Full), "pretty-print the output of the next semantic version in a given format. "+
-		"The format can be one of either full or compact. Must be used in conjunction with --show")
+		"The format can be one of either full, compact or oneline. Must be used in conjunction with --show")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
 	flags.BoolVarP(&opts.Show, "show", "s", false, "show how the next semantic version was generated")
-
+	flags.StringVar(&opts.SummaryFormat, "summary-format", string(tui.Terminal), "the format to render the summary in. The format "+
+		"can be one of either terminal, markdown or html. Must be used in conjunction with --show")
+	flags.BoolVar(&opts.VPrefix, "v-prefix", false, "prefix the first tag with a v, if it is the convention of the "+
+		"detected language, such as node, rust or terraform")
+
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("go-module", goModuleFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("pretty", prettyFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("summary-format", summaryFormatFlagShellComp)
 	return cmd
 }
 
@@ -121,16 +200,11 @@ func doPatch(gitc *git.Client, opts *Options) error {
 
 	var vers []*nsv.Next
 	for _, path := range opts.Paths {
-		next, err := nsv.NextVersion(gitc, nsv.Options{
-			FixShallow:    opts.FixShallow,
-			Hook:          opts.Hook,
-			MajorPrefixes: opts.MajorPrefixes,
-			MinorPrefixes: opts.MinorPrefixes,
-			Logger:        opts.Logger,
-			PatchPrefixes: opts.PatchPrefixes,
-			Path:          path,
-			VersionFormat: opts.VersionFormat,
-		})
+		nextVersionOpts := nextOptions(opts, path)
+		nextVersionOpts.GoModule = opts.GoModule
+		nextVersionOpts.Hook = opts.Hook
+
+		next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nextVersionOpts)
 		if err != nil {
 			return err
 		}
@@ -157,7 +231,7 @@ func doPatch(gitc *git.Client, opts *Options) error {
 	}
 
 	printNext(vers, opts)
-	return nil
+	return writeCIOutputs(vers, opts)
 }
 
 func commitChanges(gitc *git.Client, ver *nsv.Next, impersonate bool, opts *Options) error {
@@ -184,11 +258,11 @@ func commitChanges(gitc *git.Client, ver *nsv.Next, impersonate bool, opts *Opti
 		SkipPipelineTag: ci.Detect().SkipPipelineTag,
 	}
 
-	_, err = stageAndCommit(gitc, cfg, ver.Diffs, rel, opts)
+	_, err = stageAndCommit(nsv.NewGitRepository(gitc).WithConfig(cfg...), ver.Diffs, rel, opts)
 	return err
 }
 
-func stageAndCommit(gitc *git.Client, cfg []string, changes []git.FileDiff, rel release, opts *Options) (string, error) {
+func stageAndCommit(repo nsv.Repository, changes []git.FileDiff, rel release, opts *Options) (string, error) {
 	if len(changes) == 0 {
 		return "", nil
 	}
@@ -198,28 +272,15 @@ func stageAndCommit(gitc *git.Client, cfg []string, changes []git.FileDiff, rel
 		paths = append(paths, change.Path)
 	}
 
-	if _, err := gitc.Stage(git.WithPathSpecs(paths...)); err != nil {
-		return "", err
-	}
-
 	opts.Logger.Debug("inputs to patch commit template", "tag", rel.Tag, "prev_tag", rel.PrevTag, "skip_ci", rel.SkipPipelineTag)
 	var buf bytes.Buffer
 	commitTmpl.Execute(&buf, rel)
 
-	msg, err := gitc.Commit(buf.String(), git.WithCommitConfig(cfg...))
-	if err != nil {
-		return "", err
-	}
-
-	_, marker, err := chomp.BracketSquare()(msg)
+	hash, err := repo.Commit(buf.String(), paths...)
 	if err != nil {
 		return "", err
 	}
 
-	_, ext, err := chomp.SepPair(chomp.Until(" "), chomp.Tag(" "), chomp.Eol())(marker)
-	if err != nil {
-		return "", err
-	}
-	opts.Logger.Info("committed patched files", "commit", buf.String(), "hash", ext[1])
-	return ext[1], nil
+	opts.Logger.Info("committed patched files", "commit", buf.String(), "hash", hash)
+	return hash, nil
 }
@@ -1,53 +1,273 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
 	"github.com/caarlos0/env/v11"
+	"github.com/purpleclay/nsv/internal/ci"
 	"github.com/purpleclay/nsv/internal/nsv"
 	"github.com/purpleclay/nsv/internal/tui"
 	"github.com/spf13/cobra"
 )
 
-var playgroundLongDesc = `A playground for discovering go template support.
+const (
+	commitSeparator = "---"
+	readFromStdin   = "-"
+)
+
+var playgroundLongDesc = `A playground for discovering go template support and simulating releases.
 
 Discover ways of formatting your repository tag using the in-built
 go template annotations.
 
+Simulate a release by providing a list of commit messages, made after
+the given tag. The increment, next version, formatted tag and rendered
+tag and commit messages are shown. Commits are read from a file, or stdin
+if set to -, ordered from oldest to newest. Each line is a commit, unless
+a line containing only --- is used to separate multi-line commits.
+Nothing within the repository is changed.
+
 Environment Variables:
 
-| Name       | Description                                       |
-|------------|---------------------------------------------------|
-| NSV_FORMAT | set a go template for formatting the provided tag |`
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| NSV_COMMIT_MESSAGE  | a custom message when committing file changes, supports go     |
+|                     | text templates. The default is: "chore: patched files for      |
+|                     | release {{.Tag}} {{.SkipPipelineTag}}"                         |
+| NSV_COMMITS         | a file of commit messages to simulate a release from, or - to  |
+|                     | read them from stdin                                           |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_FORMAT          | set a go template for formatting the provided tag              |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_INITIAL_VERSION | the version to release when no previous tag exists, rather     |
+|                     | than bumping from 0.0.0, e.g. 1.0.0                            |
+| NSV_MAJOR_PATTERN   | a regular expression for triggering a major semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MIN_VERSION     | a minimum version that the next version will be raised to if   |
+|                     | it would otherwise fall below it                               |
+| NSV_MINOR_PATTERN   | a regular expression for triggering a minor semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATCH_PATTERN   | a regular expression for triggering a patch semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_PRETTY          | pretty-print the simulated history in a given format. The      |
+|                     | format can be one of either full, compact or oneline           |
+|                     | (default: full)                                                |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |
+| NSV_TAG_MESSAGE     | a custom message for the annotated tag, supports go text       |
+|                     | templates. The default is: "chore: tagged release {{.Tag}}"    |`
 
 func playgroundCmd(opts *Options) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:    "playground <tag>",
-		Short:  "A playground for discovering go template support",
+		Use:    "playground [<tag>]",
+		Short:  "A playground for discovering go template support and simulating releases",
 		Long:   playgroundLongDesc,
-		Args:   cobra.ExactArgs(1),
+		Args:   cobra.MaximumNArgs(1),
 		Hidden: true,
-		PreRunE: func(_ *cobra.Command, _ []string) error {
-			return env.Parse(opts)
-		},
-		RunE: func(_ *cobra.Command, args []string) error {
-			if err := nsv.CheckTemplate(opts.VersionFormat); err != nil {
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if err := env.Parse(opts); err != nil {
 				return err
 			}
 
-			tag, err := nsv.ParseTag(args[0])
-			if err != nil {
+			if opts.Commits == "" {
+				return cobra.ExactArgs(1)(nil, args)
+			}
+
+			for _, templatedText := range []string{opts.TagMessage, opts.CommitMessage} {
+				if err := verifyTextTemplate(templatedText); err != nil {
+					return err
+				}
+			}
+
+			if err := supportedPrettyFormat(opts.Pretty); err != nil {
 				return err
 			}
 
-			tui.PrintFormat(tag, tui.PlaygroundOptions{
-				Out:           opts.Err,
-				VersionFormat: opts.VersionFormat,
-			})
-			return nil
+			return versionChecks(opts)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if opts.Commits == "" {
+				return doFormat(args[0], opts)
+			}
+
+			var tag string
+			if len(args) > 0 {
+				tag = args[0]
+			}
+			return doSimulate(tag, opts)
 		},
 	}
 
 	flags := cmd.Flags()
+	flags.StringVarP(&opts.CommitMessage, "commit-message", "M", tagCommitMessageTmpl, "a custom message when committing file "+
+		"changes, supports go text templates")
+	flags.StringVar(&opts.Commits, "commits", "", "a file of commit messages to simulate a release from, or - to read them from stdin")
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
 	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.StringVar(&opts.InitialVersion, "initial-version", "", "the version to release when no previous tag exists, "+
+		"rather than bumping from 0.0.0, e.g. 1.0.0")
+	flags.StringVar(&opts.MajorPattern, "major-pattern", "", "a regular expression for triggering a major semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a major semantic version increment")
+	flags.StringVar(&opts.MinVersion, "min-version", "", "a minimum version that the next version will be raised to "+
+		"if it would otherwise fall below it")
+	flags.StringVar(&opts.MinorPattern, "minor-pattern", "", "a regular expression for triggering a minor semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a minor semantic version increment")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.PatchPattern, "patch-pattern", "", "a regular expression for triggering a patch semantic version "+
+		"increment. Must be used with the regex convention")
+	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a patch semantic version increment")
+	flags.StringVarP(&opts.Pretty, "pretty", "p", string(tui.Full), "pretty-print the simulated history in a given format. "+
+		"The format can be one of either full, compact or oneline")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
+	flags.StringVarP(&opts.TagMessage, "tag-message", "A", tagMessageTmpl, "a custom message for the annotated tag, supports go text templates")
 
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("pretty", prettyFlagShellComp)
 	return cmd
 }
+
+func doFormat(raw string, opts *Options) error {
+	if err := nsv.CheckTemplate(opts.VersionFormat); err != nil {
+		return err
+	}
+
+	tag, err := nsv.ParseTag(raw)
+	if err != nil {
+		return err
+	}
+
+	tui.PrintFormat(tag, tui.PlaygroundOptions{
+		Out:           opts.Err,
+		VersionFormat: opts.VersionFormat,
+	})
+	return nil
+}
+
+func doSimulate(tag string, opts *Options) error {
+	commits, err := readCommits(opts.Commits, opts.In)
+	if err != nil {
+		return err
+	}
+
+	sim, err := nsv.Simulate(nsv.Scenario{Tag: tag, Commits: commits}, nextOptions(opts, ""))
+	if err != nil {
+		return err
+	}
+
+	scenario := tui.Scenario{Simulation: sim}
+	if sim.Next != nil {
+		rel := release{
+			Tag:             sim.Next.Tag,
+			PrevTag:         sim.Next.PrevTag,
+			SkipPipelineTag: ci.Detect().SkipPipelineTag,
+		}
+		scenario.TagMessage = renderTemplate(opts.TagMessage, rel)
+		scenario.CommitMessage = renderTemplate(opts.CommitMessage, rel)
+	}
+
+	width := opts.Columns
+	if width == 0 {
+		width = tui.TerminalWidth(opts.Err)
+	}
+
+	tui.PrintScenario(scenario, tui.ScenarioOptions{
+		NoColor: opts.NoColor,
+		Out:     opts.Err,
+		Pretty:  tui.Pretty(opts.Pretty),
+		Width:   width,
+	})
+	return nil
+}
+
+// readCommits reads a list of commit messages from a file, or stdin. Each line is a
+// commit, unless a separator is used to delimit multi-line commits
+func readCommits(path string, stdin io.Reader) ([]string, error) {
+	var in io.Reader = stdin
+	if path != readFromStdin {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var lines []string
+	separated := false
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == commitSeparator {
+			separated = true
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var commits []string
+	if !separated {
+		for _, line := range lines {
+			if line = strings.TrimSpace(line); line != "" {
+				commits = append(commits, line)
+			}
+		}
+		return commits, nil
+	}
+
+	var msg []string
+	for _, line := range append(lines, commitSeparator) {
+		if strings.TrimSpace(line) != commitSeparator {
+			msg = append(msg, line)
+			continue
+		}
+
+		if commit := strings.TrimSpace(strings.Join(msg, "\n")); commit != "" {
+			commits = append(commits, commit)
+		}
+		msg = nil
+	}
+
+	return commits, nil
+}
+
+func renderTemplate(text string, rel release) string {
+	tmpl, _ := template.New("playground-template").Parse(text)
+
+	var buf bytes.Buffer
+	tmpl.Execute(&buf, rel)
+	return buf.String()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaygroundSimulate(t *testing.T) {
+	commits := `fix: search results not sorted
+feat(internal): cache search indexes`
+
+	var buf bytes.Buffer
+	cmd := playgroundCmd(&Options{In: strings.NewReader(commits), Out: io.Discard, Err: &buf, Logger: noopLogger})
+	cmd.SetArgs([]string{"ui/v0.1.0", "--commits", "-", "--rules", "feat(internal)=patch", "--tag-message", "release {{.Tag}} from {{.PrevTag}}"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "patch")
+	assert.Contains(t, buf.String(), "ui/v0.1.1")
+	assert.Contains(t, buf.String(), "release ui/v0.1.1 from ui/v0.1.0")
+	assert.Contains(t, buf.String(), "chore: patched files for release ui/v0.1.1")
+}
+
+func TestPlaygroundSimulateNoIncrement(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := playgroundCmd(&Options{In: strings.NewReader("docs: document search options"), Out: io.Discard, Err: &buf, Logger: noopLogger})
+	cmd.SetArgs([]string{"0.1.0", "--commits", "-"})
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "no release would be triggered")
+}
+
+func TestPlaygroundRequiresTagWithoutCommits(t *testing.T) {
+	cmd := playgroundCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "accepts 1 arg(s), received 0")
+}
+
+func TestReadCommits(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "SingleLine",
+			input: `fix: search results not sorted
+
+feat: support pagination of search results
+`,
+			expected: []string{"fix: search results not sorted", "feat: support pagination of search results"},
+		},
+		{
+			name: "Separated",
+			input: `refactor: restructure search indexes
+
+BREAKING CHANGE: indexes must be rebuilt
+---
+fix: search results not sorted
+---
+`,
+			expected: []string{
+				"refactor: restructure search indexes\n\nBREAKING CHANGE: indexes must be rebuilt",
+				"fix: search results not sorted",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := readCommits(readFromStdin, strings.NewReader(tt.input))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, commits)
+		})
+	}
+}
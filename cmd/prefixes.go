@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var prefixesLongDesc = `Print the effective table of rules used to detect the next semantic version
+increment from a conventional commit. Any provided rules are applied on top of
+the list of prefixes, with the most specific rule for a type and scope winning.
+
+Environment Variables:
+
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |`
+
+func prefixesCmd(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prefixes",
+		Short: "Print the effective rules for detecting the next increment",
+		Long:  prefixesLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			rules, err := nsv.AngularRules(nextOptions(opts, ""))
+			if err != nil {
+				return err
+			}
+
+			tui.PrintRules(rules, tui.RulesOptions{Out: opts.Out})
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a major semantic version increment")
+	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a minor semantic version increment")
+	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
+		"triggering a patch semantic version increment")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
+
+	return cmd
+}
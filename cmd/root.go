@@ -18,24 +18,59 @@ import (
 var logLevels = []string{"debug", "info", "warn", "error", "fatal"}
 
 type Options struct {
-	CommitMessage string      `env:"NSV_COMMIT_MESSAGE"`
-	DryRun        bool        `env:"NSV_DRY_RUN"`
-	Err           io.Writer   `env:"-"`
-	FixShallow    bool        `env:"NSV_FIX_SHALLOW"`
-	Hook          string      `env:"NSV_HOOK"`
-	Logger        *log.Logger `env:"-"`
-	LogLevel      string      `env:"LOG_LEVEL"`
-	MajorPrefixes []string    `env:"NSV_MAJOR_PREFIXES"`
-	MinorPrefixes []string    `env:"NSV_MINOR_PREFIXES"`
-	NoColor       bool        `env:"NO_COLOR"`
-	NoLog         bool        `env:"NO_LOG"`
-	Out           io.Writer   `env:"-"`
-	PatchPrefixes []string    `env:"NSV_PATCH_PREFIXES"`
-	Paths         []string    `env:"-"`
-	Pretty        string      `env:"NSV_PRETTY"`
-	Show          bool        `env:"NSV_SHOW"`
-	TagMessage    string      `env:"NSV_TAG_MESSAGE"`
-	VersionFormat string      `env:"NSV_FORMAT"`
+	AllowAuthors   []string    `env:"NSV_ALLOW_AUTHORS"`
+	AllowBranches  []string    `env:"NSV_ALLOW_BRANCHES"`
+	Boundary       string      `env:"NSV_BOUNDARY"`
+	Branch         string      `env:"NSV_BRANCH"`
+	Columns        int         `env:"COLUMNS"`
+	CommitMessage  string      `env:"NSV_COMMIT_MESSAGE"`
+	Commits        string      `env:"NSV_COMMITS"`
+	Convention     string      `env:"NSV_CONVENTION"`
+	Create         bool        `env:"NSV_CREATE"`
+	DryRun         bool        `env:"NSV_DRY_RUN"`
+	Err            io.Writer   `env:"-"`
+	FixShallow     bool        `env:"NSV_FIX_SHALLOW"`
+	GoModule       string      `env:"NSV_GO_MODULE"`
+	Hook           string      `env:"NSV_HOOK"`
+	IgnoreAuthors  []string    `env:"NSV_IGNORE_AUTHORS"`
+	IgnoreCommits  []string    `env:"NSV_IGNORE_COMMITS"`
+	In             io.Reader   `env:"-"`
+	InitialVersion string      `env:"NSV_INITIAL_VERSION"`
+	Interactive    bool        `env:"NSV_INTERACTIVE"`
+	Logger         *log.Logger `env:"-"`
+	LogLevel       string      `env:"LOG_LEVEL"`
+	MajorPattern   string      `env:"NSV_MAJOR_PATTERN"`
+	MajorPrefixes  []string    `env:"NSV_MAJOR_PREFIXES"`
+	MaxLines       int         `env:"NSV_MAX_LINES"`
+	MinVersion     string      `env:"NSV_MIN_VERSION"`
+	MinorPattern   string      `env:"NSV_MINOR_PATTERN"`
+	MinorPrefixes  []string    `env:"NSV_MINOR_PREFIXES"`
+	NoCIOutput     bool        `env:"NSV_NO_CI_OUTPUT"`
+	NoColor        bool        `env:"NO_COLOR"`
+	NoIgnores      bool        `env:"NSV_NO_IGNORES"`
+	NoLog          bool        `env:"NO_LOG"`
+	OnCollision    string      `env:"NSV_ON_COLLISION"`
+	Out            io.Writer   `env:"-"`
+	Output         string      `env:"NSV_OUTPUT"`
+	ParseBody      bool        `env:"NSV_PARSE_BODY"`
+	Path           string      `env:"NSV_PATH"`
+	PatchPattern   string      `env:"NSV_PATCH_PATTERN"`
+	PatchPrefixes  []string    `env:"NSV_PATCH_PREFIXES"`
+	Paths          []string    `env:"-"`
+	PRBase         string      `env:"NSV_PR_BASE"`
+	PRPreview      bool        `env:"NSV_PR_PREVIEW"`
+	PRPreviewOut   string      `env:"NSV_PR_PREVIEW_OUT"`
+	Pretty         string      `env:"NSV_PRETTY"`
+	Ref            string      `env:"NSV_REF"`
+	Rules          []string    `env:"NSV_RULES"`
+	Show           bool        `env:"NSV_SHOW"`
+	Since          string      `env:"NSV_SINCE"`
+	SummaryFormat  string      `env:"NSV_SUMMARY_FORMAT"`
+	TagMessage     string      `env:"NSV_TAG_MESSAGE"`
+	TagTarget      []string    `env:"NSV_TAG_TARGET"`
+	TagType        string      `env:"NSV_TAG_TYPE"`
+	VersionFormat  string      `env:"NSV_FORMAT"`
+	VPrefix        bool        `env:"NSV_V_PREFIX"`
 }
 
 var rootLongDesc = `NSV (Next Semantic Version) is a convention-based semantic versioning tool that
@@ -72,6 +107,7 @@ type BuildDetails struct {
 func Execute(out io.Writer, buildInfo BuildDetails) error {
 	opts := &Options{
 		Err: os.Stderr,
+		In:  os.Stdin,
 		Out: out,
 	}
 
@@ -121,6 +157,10 @@ func Execute(out io.Writer, buildInfo BuildDetails) error {
 		nextCmd(opts),
 		tagCmd(opts),
 		patchCmd(opts),
+		prefixesCmd(opts),
+		backfillCmd(opts),
+		historyCmd(opts),
+		diffCmd(opts),
 	)
 
 	cmd.SetUsageTemplate(customUsageTemplate)
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -40,6 +43,45 @@ func (e TemplateSyntaxError) Error() string {
 	return out.String()
 }
 
+type UnresolvedBranchError struct{}
+
+func (UnresolvedBranchError) Error() string {
+	return "unable to push changes from a detached HEAD as the branch could not be resolved, " +
+		"please provide one using --branch"
+}
+
+type UnsupportedTagTypeError struct {
+	Type string
+}
+
+func (e UnsupportedTagTypeError) Error() string {
+	return fmt.Sprintf("tag type '%s' is not supported, must be one of either: %s",
+		e.Type, strings.Join(tagTypes, ", "))
+}
+
+type UnsupportedTagTargetError struct {
+	Target string
+}
+
+func (e UnsupportedTagTargetError) Error() string {
+	return fmt.Sprintf("tag target '%s' is not supported, must be one of either: %s, optionally scoped to a path <path>=<target>",
+		e.Target, strings.Join(tagTargets, ", "))
+}
+
+const (
+	annotatedTag   = "annotated"
+	lightweightTag = "lightweight"
+	tagTargetPatch = "patch"
+	tagTargetHead  = "head"
+)
+
+var (
+	tagTypes   = []string{annotatedTag, lightweightTag}
+	tagTargets = []string{tagTargetPatch, tagTargetHead}
+
+	errPatchWithRef = errors.New("patching files with a hook or go module strategy is not supported when tagging a ref other than HEAD")
+)
+
 type release struct {
 	Tag             string
 	PrevTag         string
@@ -56,31 +98,94 @@ your repository.
 
 Environment Variables:
 
-| Name               | Description                                                    |
-|--------------------|----------------------------------------------------------------|
-| LOG_LEVEL          | the level of logging when printing to stderr (default: info)   |
-| NO_COLOR           | switch to using an ASCII color profile within the terminal     |
-| NO_LOG             | disable all log output                                         |
-| NSV_COMMIT_MESSAGE | a custom message when committing file changes, supports go     |
-|                    | text templates. The default is: "chore: patched files for      |
-|                    | release {{.Tag}} {{.SkipPipelineTag}}"                         |
-| NSV_DRY_RUN        | no changes will be made to the repository                      |
-| NSV_FIX_SHALLOW    | fix a shallow clone of a repository if detected                |
-| NSV_FORMAT         | provide a go template for changing the default version format  |
-| NSV_HOOK           | a user-defined hook that will be executed before the           |
-|                    | repository is tagged with the next semantic version            |
-| NSV_MAJOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a major semantic version increment                  |
-| NSV_MINOR_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a minor semantic version increment                  |
-| NSV_PATCH_PREFIXES | a comma separated list of conventional commit prefixes for     |
-|                    | triggering a patch semantic version increment                  |
-| NSV_PRETTY         | pretty-print the output of the next semantic version in a      |
-|                    | given format. The format can be one of either full or compact. |
-|                    | Must be used in conjunction with NSV_SHOW (default: full)      |
-| NSV_SHOW           | show how the next semantic version was generated               |
-| NSV_TAG_MESSAGE    | a custom message for the annotated tag, supports go text       |
-|                    | templates. The default is: "chore: tagged release {{.Tag}}"    |
+| Name                | Description                                                    |
+|---------------------|----------------------------------------------------------------|
+| COLUMNS             | the width of the terminal when printing a summary, detected    |
+|                     | automatically if not set                                       |
+| LOG_LEVEL           | the level of logging when printing to stderr (default: info)   |
+| NO_COLOR            | switch to using an ASCII color profile within the terminal     |
+| NO_LOG              | disable all log output                                         |
+| NSV_ALLOW_AUTHORS   | a comma separated list of author names or emails that are      |
+|                     | allowed to trigger a release                                   |
+| NSV_ALLOW_BRANCHES  | a comma separated list of branches, supporting glob patterns,  |
+|                     | that are allowed to be released from. Enables checks for a     |
+|                     | clean working tree and HEAD matching the remote branch tip     |
+| NSV_BRANCH          | the branch to push changes to when the repository has a        |
+|                     | detached HEAD. If not set, it will be resolved from the CI     |
+|                     | environment, unless building a pull request                    |
+| NSV_COMMIT_MESSAGE  | a custom message when committing file changes, supports go     |
+|                     | text templates. The default is: "chore: patched files for      |
+|                     | release {{.Tag}} {{.SkipPipelineTag}}"                         |
+| NSV_CONVENTION      | the commit convention used to detect the next increment. The   |
+|                     | convention can be one of either angular, gitmoji or regex      |
+|                     | (default: angular)                                             |
+| NSV_DRY_RUN         | no changes will be made to the repository                      |
+| NSV_FIX_SHALLOW     | fix a shallow clone of a repository if detected                |
+| NSV_FORMAT          | provide a go template for changing the default version format  |
+| NSV_GO_MODULE       | the strategy to apply when a go module path does not match the |
+|                     | major version of the next tag. The strategy can be one of      |
+|                     | either warn, fail or patch. If not set, no check is made       |
+| NSV_HOOK            | a user-defined hook that will be executed before the           |
+|                     | repository is tagged with the next semantic version            |
+| NSV_IGNORE_AUTHORS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their author email                                  |
+| NSV_IGNORE_COMMITS  | a comma separated list of regular expressions for ignoring     |
+|                     | commits by their message                                       |
+| NSV_INITIAL_VERSION | the version to release when no previous tag exists, rather     |
+|                     | than bumping from 0.0.0, e.g. 1.0.0                            |
+| NSV_INTERACTIVE     | review each release within the terminal before it is tagged,   |
+|                     | allowing commits to be excluded, the increment overridden and  |
+|                     | the tag message edited                                         |
+| NSV_MAJOR_PATTERN   | a regular expression for triggering a major semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MAJOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a major semantic version increment                  |
+| NSV_MAX_LINES       | the maximum number of lines of each commit message to show     |
+|                     | within a summary. Messages are never truncated if set to 0     |
+| NSV_MIN_VERSION     | a minimum version that the next version will be raised to if   |
+|                     | it would otherwise fall below it                               |
+| NSV_MINOR_PATTERN   | a regular expression for triggering a minor semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_MINOR_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a minor semantic version increment                  |
+| NSV_NO_CI_OUTPUT    | disable writing outputs native to the detected CI platform,    |
+|                     | such as GitHub step outputs or a GitLab dotenv file            |
+| NSV_NO_IGNORES      | disable the built-in rules for ignoring fixup!, squash!,       |
+|                     | amend!, merge branch, WIP and dependency bot commits           |
+| NSV_ON_COLLISION    | the strategy to apply when the next tag already exists locally |
+|                     | or on the remote. The strategy can be one of either fail, skip |
+|                     | or bump. If not set, no check is made                          |
+| NSV_PARSE_BODY      | parse bullet-listed conventional commits within the body of    |
+|                     | squash and merge commits when detecting the increment          |
+| NSV_PATCH_PATTERN   | a regular expression for triggering a patch semantic version   |
+|                     | increment. Must be used with the regex convention              |
+| NSV_PATCH_PREFIXES  | a comma separated list of conventional commit prefixes for     |
+|                     | triggering a patch semantic version increment                  |
+| NSV_PRETTY          | pretty-print the output of the next semantic version in a      |
+|                     | given format. The format can be one of either full, compact or |
+|                     | oneline. Must be used in conjunction with NSV_SHOW             |
+|                     | (default: full)                                                |
+| NSV_REF             | calculate the next semantic version at a given commit-ish,     |
+|                     | rather than HEAD. Only tags reachable from it are considered   |
+| NSV_RULES           | a comma separated list of rules mapping a conventional commit  |
+|                     | type and optional scope to an increment, type[(scope)]=inc.    |
+|                     | Rules take precedence over prefixes, e.g. feat(internal)=patch |
+| NSV_SHOW            | show how the next semantic version was generated               |
+| NSV_SINCE           | calculate the next semantic version from a given tag, rather   |
+|                     | than the latest tag                                            |
+| NSV_SUMMARY_FORMAT  | the format to render the summary in. The format can be one of  |
+|                     | either terminal, markdown or html. Must be used in conjunction |
+|                     | with NSV_SHOW (default: terminal)                              |
+| NSV_TAG_MESSAGE     | a custom message for the annotated tag, supports go text       |
+|                     | templates. The default is: "chore: tagged release {{.Tag}}"    |
+| NSV_TAG_TARGET      | the commit a tag points to when a hook patches files, either   |
+|                     | the patch commit or the pre-patch HEAD. The target can be one  |
+|                     | of either patch or head, and can be scoped to a path using     |
+|                     | <path>=<target> (default: patch)                               |
+| NSV_TAG_TYPE        | the type of tag to create. The type can be one of either       |
+|                     | annotated or lightweight (default: annotated)                  |
+| NSV_V_PREFIX        | prefix the first tag with a v, if it is the convention of the  |
+|                     | detected language, such as node, rust or terraform             |
 
 Hook Environment Variables:
 
@@ -110,6 +215,16 @@ func tagCmd(opts *Options) *cobra.Command {
 			tagTmpl, _ = template.New("tag-template").Parse(opts.TagMessage)
 			commitTmpl, _ = template.New("commit-template").Parse(opts.CommitMessage)
 
+			if opts.OnCollision != "" {
+				if err := nsv.CheckCollisionStrategy(opts.OnCollision); err != nil {
+					return err
+				}
+			}
+
+			if err := checkTagOptions(opts); err != nil {
+				return err
+			}
+
 			return preRunChecks(opts)
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -127,28 +242,156 @@ func tagCmd(opts *Options) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.AllowAuthors, "allow-authors", []string{}, "a comma separated list of author names or emails that are "+
+		"allowed to trigger a release")
+	flags.StringSliceVar(&opts.AllowBranches, "allow-branches", []string{}, "a comma separated list of branches, supporting glob "+
+		"patterns, that are allowed to be released from. Enables checks for a clean working tree and HEAD matching the remote branch tip")
+	flags.StringVar(&opts.Branch, "branch", "", "the branch to push changes to when the repository has a detached HEAD. "+
+		"If not set, it will be resolved from the CI environment, unless building a pull request")
 	flags.StringVarP(&opts.CommitMessage, "commit-message", "M", tagCommitMessageTmpl, "a custom message when committing file "+
 		"changes, supports go text templates")
+	flags.StringVar(&opts.Convention, "convention", nsv.AngularConvention, "the commit convention used to detect the next "+
+		"increment. The convention can be one of either angular, gitmoji or regex")
 	flags.BoolVar(&opts.DryRun, "dry-run", false, "no changes will be made to the repository")
 	flags.BoolVar(&opts.FixShallow, "fix-shallow", false, "fix a shallow clone of a repository if detected")
+	flags.StringVar(&opts.GoModule, "go-module", "", "the strategy to apply when a go module path does not match "+
+		"the major version of the next tag. The strategy can be one of either warn, fail or patch. If not set, no check is made")
 	flags.StringVar(&opts.Hook, "hook", "", "a user-defined hook that will be executed before the repository is tagged "+
 		"with the next semantic version")
 	flags.StringVarP(&opts.VersionFormat, "format", "f", "", "provide a go template for changing the default version format")
 	flags.StringVarP(&opts.TagMessage, "tag-message", "A", tagMessageTmpl, "a custom message for the annotated tag, supports go text templates")
+	flags.StringSliceVar(&opts.IgnoreAuthors, "ignore-authors", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their author email")
+	flags.StringSliceVar(&opts.IgnoreCommits, "ignore-commits", []string{}, "a comma separated list of regular expressions for "+
+		"ignoring commits by their message")
+	flags.StringVar(&opts.InitialVersion, "initial-version", "", "the version to release when no previous tag exists, "+
+		"rather than bumping from 0.0.0, e.g. 1.0.0")
+	flags.BoolVarP(&opts.Interactive, "interactive", "i", false, "review each release within the terminal before it is tagged, "+
+		"allowing commits to be excluded, the increment overridden and the tag message edited")
+	flags.StringVar(&opts.MajorPattern, "major-pattern", "", "a regular expression for triggering a major semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MajorPrefixes, "major-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a major semantic version increment")
+	flags.IntVar(&opts.MaxLines, "max-lines", 0, "the maximum number of lines of each commit message to show within a summary. "+
+		"Messages are never truncated if set to 0")
+	flags.StringVar(&opts.MinVersion, "min-version", "", "a minimum version that the next version will be raised to "+
+		"if it would otherwise fall below it")
+	flags.StringVar(&opts.MinorPattern, "minor-pattern", "", "a regular expression for triggering a minor semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.MinorPrefixes, "minor-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a minor semantic version increment")
+	flags.BoolVar(&opts.NoCIOutput, "no-ci-output", false, "disable writing outputs native to the detected CI platform, "+
+		"such as GitHub step outputs or a GitLab dotenv file")
+	flags.BoolVar(&opts.NoIgnores, "no-ignores", false, "disable the built-in rules for ignoring fixup!, squash!, amend!, "+
+		"merge branch, WIP and dependency bot commits")
+	flags.StringVar(&opts.OnCollision, "on-collision", "", "the strategy to apply when the next tag already exists "+
+		"locally or on the remote. The strategy can be one of either fail, skip or bump. If not set, no check is made")
+	flags.BoolVar(&opts.ParseBody, "parse-body", false, "parse bullet-listed conventional commits within the body of squash "+
+		"and merge commits when detecting the increment")
+	flags.StringVar(&opts.PatchPattern, "patch-pattern", "", "a regular expression for triggering a patch semantic version "+
+		"increment. Must be used with the regex convention")
 	flags.StringSliceVar(&opts.PatchPrefixes, "patch-prefixes", []string{}, "a comma separated list of conventional commit prefixes for "+
 		"triggering a patch semantic version increment")
 	flags.StringVarP(&opts.Pretty, "pretty", "p", string(tui.Full), "pretty-print the output of the next semantic version in a given format. "+
-		"The format can be one of either full or compact. Must be used in conjunction with --show")
+		"The format can be one of either full, compact or oneline. Must be used in conjunction with --show")
+	flags.StringVar(&opts.Ref, "ref", "", "calculate the next semantic version at a given commit-ish, rather than HEAD. "+
+		"Only tags reachable from it are considered")
+	flags.StringSliceVar(&opts.Rules, "rules", []string{}, "a comma separated list of rules mapping a conventional commit type and "+
+		"optional scope to an increment, type[(scope)]=increment. Rules take precedence over prefixes, e.g. feat(internal)=patch")
 	flags.BoolVarP(&opts.Show, "show", "s", false, "show how the next semantic version was generated")
-
+	flags.StringVar(&opts.Since, "since", "", "calculate the next semantic version from a given tag, rather than the latest tag")
+	flags.StringVar(&opts.SummaryFormat, "summary-format", string(tui.Terminal), "the format to render the summary in. The format "+
+		"can be one of either terminal, markdown or html. Must be used in conjunction with --show")
+	flags.StringSliceVar(&opts.TagTarget, "tag-target", []string{tagTargetPatch}, "the commit a tag points to when a hook patches "+
+		"files, either the patch commit or the pre-patch HEAD. The target can be one of either patch or head, and can be scoped "+
+		"to a path using <path>=<target>")
+	flags.StringVar(&opts.TagType, "tag-type", annotatedTag, "the type of tag to create. The type can be one of either "+
+		"annotated or lightweight")
+	flags.BoolVar(&opts.VPrefix, "v-prefix", false, "prefix the first tag with a v, if it is the convention of the "+
+		"detected language, such as node, rust or terraform")
+
+	cmd.RegisterFlagCompletionFunc("convention", conventionFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("go-module", goModuleFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("on-collision", onCollisionFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("tag-target", tagTargetFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("tag-type", tagTypeFlagShellComp)
 	cmd.RegisterFlagCompletionFunc("pretty", prettyFlagShellComp)
+	cmd.RegisterFlagCompletionFunc("summary-format", summaryFormatFlagShellComp)
 	return cmd
 }
 
+func onCollisionFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return nsv.CollisionStrategies, cobra.ShellCompDirectiveDefault
+}
+
+func tagTargetFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return tagTargets, cobra.ShellCompDirectiveDefault
+}
+
+func tagTypeFlagShellComp(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return tagTypes, cobra.ShellCompDirectiveDefault
+}
+
+func checkTagOptions(opts *Options) error {
+	if opts.Ref != "" && (opts.Hook != "" || opts.GoModule == nsv.GoModulePatch) {
+		return errPatchWithRef
+	}
+
+	if !slices.Contains(tagTypes, opts.TagType) {
+		return UnsupportedTagTypeError{Type: opts.TagType}
+	}
+
+	for _, target := range opts.TagTarget {
+		_, t, found := strings.Cut(target, "=")
+		if !found {
+			t = target
+		}
+
+		if !slices.Contains(tagTargets, t) {
+			return UnsupportedTagTargetError{Target: target}
+		}
+	}
+
+	return nil
+}
+
+// tagTargetFor resolves the tag target for a path. A target scoped to the path
+// takes precedence over an unscoped target
+func tagTargetFor(path string, targets []string) string {
+	target := tagTargetPatch
+	for _, t := range targets {
+		scope, scopedTarget, found := strings.Cut(t, "=")
+		if !found {
+			target = t
+			continue
+		}
+
+		if filepath.Clean(scope) == filepath.Clean(path) {
+			return scopedTarget
+		}
+	}
+
+	return target
+}
+
+// collisionRemoteTags lists the tags on the push remote once, so they can be shared
+// across all paths when checking for collisions
+func collisionRemoteTags(gitc *git.Client, opts *Options) ([]string, error) {
+	if opts.OnCollision == "" {
+		return nil, nil
+	}
+
+	tags, err := nsv.NewGitRepository(gitc).RemoteTags()
+	if err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
 func verifyTextTemplate(tmpl string) error {
 	t, err := template.New("verify-template").Parse(tmpl)
 	if err != nil {
@@ -166,24 +409,40 @@ func verifyTextTemplate(tmpl string) error {
 }
 
 func doTag(gitc *git.Client, opts *Options) error {
+	if err := gateRelease(gitc, opts); err != nil {
+		return err
+	}
+
+	if err := gateAuthors(gitc, opts); err != nil {
+		return err
+	}
+
 	impersonate, err := requiresImpersonation(gitc)
 	if err != nil {
 		return err
 	}
 
+	interactive := opts.Interactive
+	if interactive && !tui.IsTerminal(opts.In, opts.Out) {
+		opts.Logger.Warn("interactive review requires a terminal, falling back to tagging without a review")
+		interactive = false
+	}
+
+	remoteTags, err := collisionRemoteTags(gitc, opts)
+	if err != nil {
+		return err
+	}
+
 	var tags []string
 	var vers []*nsv.Next
-	for _, path := range opts.Paths {
-		next, err := nsv.NextVersion(gitc, nsv.Options{
-			FixShallow:    opts.FixShallow,
-			Hook:          opts.Hook,
-			MajorPrefixes: opts.MajorPrefixes,
-			MinorPrefixes: opts.MinorPrefixes,
-			Logger:        opts.Logger,
-			PatchPrefixes: opts.PatchPrefixes,
-			Path:          path,
-			VersionFormat: opts.VersionFormat,
-		})
+	for i, path := range opts.Paths {
+		nextVersionOpts := nextOptions(opts, path)
+		nextVersionOpts.GoModule = opts.GoModule
+		nextVersionOpts.Hook = opts.Hook
+		nextVersionOpts.OnCollision = opts.OnCollision
+		nextVersionOpts.RemoteTags = remoteTags
+
+		next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nextVersionOpts)
 		if err != nil {
 			return err
 		}
@@ -192,7 +451,18 @@ func doTag(gitc *git.Client, opts *Options) error {
 			continue
 		}
 
-		if err := commitAndTag(gitc, next, impersonate, opts); err != nil {
+		var message string
+		if interactive {
+			if next, message, err = reviewRelease(gitc, next, i+1, nextVersionOpts, opts); err != nil {
+				return err
+			}
+
+			if next == nil {
+				continue
+			}
+		}
+
+		if err := commitAndTag(gitc, next, message, impersonate, opts); err != nil {
 			return err
 		}
 
@@ -210,16 +480,74 @@ func doTag(gitc *git.Client, opts *Options) error {
 	}
 
 	printNext(vers, opts)
-	return nil
+	return writeCIOutputs(vers, opts)
 }
 
-func commitAndTag(gitc *git.Client, ver *nsv.Next, impersonate bool, opts *Options) error {
-	if opts.DryRun {
-		statuses, err := gitc.PorcelainStatus()
-		if err != nil {
-			return err
+// reviewRelease interactively reviews a release before it is tagged, returning the
+// release along with any edited tag message. A nil release is returned if skipped
+func reviewRelease(gitc *git.Client, next *nsv.Next, position int, nextOpts nsv.Options, opts *Options) (*nsv.Next, string, error) {
+	repo := nsv.NewGitRepository(gitc)
+
+	reviewOpts := tui.ReviewOptions{
+		In:       opts.In,
+		Out:      opts.Out,
+		Position: position,
+		Total:    len(opts.Paths),
+		Adjust: func(adj nsv.Adjustment) (*nsv.Next, error) {
+			// Discard any files patched for the previous version before patching them again
+			if err := restoreWorkingTree(gitc); err != nil {
+				return nil, err
+			}
+			return nsv.Adjust(repo, next, adj, nextOpts)
+		},
+	}
+
+	if opts.TagType == annotatedTag {
+		reviewOpts.TagMessage = func(ver *nsv.Next) string {
+			return tagMessage(release{
+				Tag:             ver.Tag,
+				PrevTag:         ver.PrevTag,
+				SkipPipelineTag: ci.Detect().SkipPipelineTag,
+			})
 		}
-		return gitc.RestoreUsing(statuses)
+	}
+
+	result, err := tui.Review(next, reviewOpts)
+	if err != nil {
+		return nil, "", errors.Join(err, restoreWorkingTree(gitc))
+	}
+
+	if !result.Confirmed {
+		opts.Logger.Info("skipped release after review", "path", next.LogDir)
+		return nil, "", restoreWorkingTree(gitc)
+	}
+
+	return result.Next, result.TagMessage, nil
+}
+
+func restoreWorkingTree(gitc *git.Client) error {
+	statuses, err := gitc.PorcelainStatus()
+	if err != nil {
+		return err
+	}
+	return gitc.RestoreUsing(statuses)
+}
+
+func tagMessage(rel release) string {
+	var buf bytes.Buffer
+	tagTmpl.Execute(&buf, rel)
+	return buf.String()
+}
+
+// commitAndTag commits any patched files and tags the release. An annotated tag uses
+// the given message, falling back to the tag message template if empty
+func commitAndTag(gitc *git.Client, ver *nsv.Next, message string, impersonate bool, opts *Options) error {
+	ver.TagType = opts.TagType
+	ver.TagTarget = tagTargetFor(ver.LogDir, opts.TagTarget)
+
+	if opts.DryRun {
+		opts.Logger.Info("skipped tagging release in dry run mode", "tag", ver.Tag, "type", ver.TagType, "target", ver.TagTarget)
+		return restoreWorkingTree(gitc)
 	}
 
 	var cfg []string
@@ -237,28 +565,38 @@ func commitAndTag(gitc *git.Client, ver *nsv.Next, impersonate bool, opts *Optio
 		SkipPipelineTag: ci.Detect().SkipPipelineTag,
 	}
 
-	hash, err := stageAndCommit(gitc, cfg, ver.Diffs, rel, opts)
+	head, err := gitc.Exec("git rev-parse " + git.HeadRef)
+	if err != nil {
+		return err
+	}
+
+	repo := nsv.NewGitRepository(gitc).WithConfig(cfg...)
+	hash, err := stageAndCommit(repo, ver.Diffs, rel, opts)
 	if err != nil {
 		return err
 	}
 
 	if hash == "" {
 		hash = ver.Log[0].Hash
+	} else if ver.TagTarget == tagTargetHead {
+		hash = head
 	}
 
-	opts.Logger.Debug("inputs to annotated tag template", "tag", rel.Tag, "prev_tag", rel.PrevTag, "skip_ci", rel.SkipPipelineTag)
-	var buf bytes.Buffer
-	tagTmpl.Execute(&buf, rel)
+	// A lightweight tag is created without an annotation
+	var annotation string
+	if ver.TagType != lightweightTag {
+		opts.Logger.Debug("inputs to annotated tag template", "tag", rel.Tag, "prev_tag", rel.PrevTag, "skip_ci", rel.SkipPipelineTag)
+		annotation = message
+		if annotation == "" {
+			annotation = tagMessage(rel)
+		}
+	}
 
-	if _, err := gitc.Tag(ver.Tag,
-		git.WithTagConfig(cfg...),
-		git.WithCommitRef(hash),
-		git.WithLocalOnly(),
-		git.WithAnnotation(buf.String())); err != nil {
+	if err := repo.Tag(ver.Tag, hash, annotation); err != nil {
 		return err
 	}
 
-	opts.Logger.Info("tagged release with", "annotation", buf.String(), "hash", hash)
+	opts.Logger.Info("tagged release with", "type", ver.TagType, "annotation", annotation, "hash", hash)
 	return nil
 }
 
@@ -267,13 +605,7 @@ func pushAll(gitc *git.Client, tags []string, opts *Options) error {
 		return nil
 	}
 
-	// NOTE: this won't work when if the repository has a detached HEAD
-	branch, err := gitc.Exec("git branch --show-current")
-	if err != nil {
-		return err
-	}
-
-	notPushed, err := gitc.Exec(fmt.Sprintf("git log %s --not --remotes", branch))
+	notPushed, err := gitc.Exec(fmt.Sprintf("git log %s --not --remotes", git.HeadRef))
 	if err != nil {
 		return err
 	}
@@ -283,15 +615,71 @@ func pushAll(gitc *git.Client, tags []string, opts *Options) error {
 		return nil
 	}
 
+	// Only push a branch when it has commits the remote doesn't know about, otherwise
+	// a branch could be pushed onto a commit it was never meant to point at
 	var refs []string
-	refs = append(refs, branch)
+	if notPushed != "" {
+		branchRef, err := resolveBranchRef(gitc, opts)
+		if err != nil {
+			return err
+		}
+
+		if branchRef == "" {
+			return UnresolvedBranchError{}
+		}
+		refs = append(refs, branchRef)
+	}
 	refs = append(refs, tags...)
 
-	_, err = gitc.Push(git.WithRefSpecs(refs...))
+	err = nsv.NewGitRepository(gitc).Push(refs...)
 	opts.Logger.Debug("pushed all changes to remote", "ref_specs", refs)
 	return err
 }
 
+func resolveBranchRef(gitc *git.Client, opts *Options) (string, error) {
+	current, branch, err := resolveBranch(gitc, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if branch == "" || branch == current {
+		return branch, nil
+	}
+
+	return fmt.Sprintf("%s:refs/heads/%s", git.HeadRef, branch), nil
+}
+
+func resolveBranch(gitc *git.Client, opts *Options) (string, string, error) {
+	current, err := gitc.Exec("git branch --show-current")
+	if err != nil {
+		return "", "", err
+	}
+
+	branch := opts.Branch
+	if branch == "" {
+		branch = current
+	}
+
+	if branch == "" {
+		branch = ciBranch(ci.Detect(), opts)
+	}
+
+	return current, branch, nil
+}
+
+// ciBranch resolves the branch from the CI environment, as most CI platforms checkout
+// a detached HEAD. A pull request build checks out a synthetic merge commit that doesn't
+// belong to its source branch, so no branch is ever resolved
+func ciBranch(ciEnv ci.Environment, opts *Options) string {
+	if ciEnv.IsPullRequest {
+		opts.Logger.Debug("skipped resolving branch from ci environment for pull request", "ci", ciEnv.Platform)
+		return ""
+	}
+
+	opts.Logger.Debug("resolved branch from ci environment", "ci", ciEnv.Platform, "branch", ciEnv.Branch)
+	return ciEnv.Branch
+}
+
 func requiresImpersonation(gitc *git.Client) (bool, error) {
 	// If the user.name and user.email config settings are set, then no impersonation is required
 	gcfg, err := gitc.Config()
@@ -315,7 +703,7 @@ func requiresImpersonation(gitc *git.Client) (bool, error) {
 }
 
 func impersonateConfig(gitc *git.Client, next *nsv.Next) ([]string, error) {
-	hash := next.Log[next.Match.Index].Hash
+	hash := triggerHash(next)
 	commits, err := gitc.ShowCommits(hash)
 	if err != nil {
 		return nil, err
@@ -324,3 +712,12 @@ func impersonateConfig(gitc *git.Client, next *nsv.Next) ([]string, error) {
 	commit := commits[hash]
 	return []string{"user.name", commit.Committer.Name, "user.email", commit.Committer.Email}, nil
 }
+
+// triggerHash identifies the commit that triggered the next semantic version, falling
+// back to the latest commit if no single commit can be attributed
+func triggerHash(next *nsv.Next) string {
+	if next.Match.Index < 0 || next.Match.Index >= len(next.Log) {
+		return next.Log[0].Hash
+	}
+	return next.Log[next.Match.Index].Hash
+}
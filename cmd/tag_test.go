@@ -3,9 +3,11 @@ package cmd
 import (
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/ci"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -127,3 +129,258 @@ func execFile(t *testing.T, path, content string) {
 	t.Helper()
 	require.NoError(t, os.WriteFile(path, []byte(content), 0o755))
 }
+
+func TestTagDetachedHeadWithBranch(t *testing.T) {
+	log := `feat: support exporting metrics in prometheus format
+(tag: 0.1.0) feat: capture application metrics`
+	gittest.InitRepository(t,
+		gittest.WithLog(log),
+		gittest.WithCommittedFiles("VERSION"),
+		gittest.WithFileContent("VERSION", "0.1.0"),
+	)
+	gittest.Checkout(t, gittest.LastCommit(t).Hash)
+
+	execFile(t, "patch-version.sh", `#!/bin/bash
+echo -n $NSV_NEXT_TAG > VERSION`)
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--hook", "./patch-version.sh", "--branch", "main"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	remoteLog := gittest.RemoteLog(t)
+	assert.Equal(t, "chore: patched files for release 0.2.0 [skip ci]", remoteLog[0].Message)
+	assert.Contains(t, gittest.RemoteTags(t), "0.2.0")
+}
+
+func TestTagDetachedHeadPushesOnlyTags(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.Checkout(t, gittest.LastCommit(t).Hash)
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, gittest.RemoteTags(t), "0.2.0")
+}
+
+func TestCIBranchPullRequest(t *testing.T) {
+	ciEnv := ci.Environment{
+		Platform:      ci.GitHubActions,
+		Branch:        "feat/tracing",
+		IsPullRequest: true,
+		PullRequest:   "12",
+		TargetBranch:  "main",
+	}
+
+	branch := ciBranch(ciEnv, &Options{Logger: noopLogger})
+	assert.Empty(t, branch)
+}
+
+func TestCIBranch(t *testing.T) {
+	ciEnv := ci.Environment{
+		Platform: ci.GitHubActions,
+		Branch:   "main",
+	}
+
+	branch := ciBranch(ciEnv, &Options{Logger: noopLogger})
+	assert.Equal(t, "main", branch)
+}
+
+func TestTagAllowBranches(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-branches", "release/*,main"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, gittest.RemoteTags(t), "0.2.0")
+}
+
+func TestTagAllowBranchesNotAllowed(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.MustExec(t, "git checkout -b feat/tracing")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-branches", "main,release/*"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "releasing from branch 'feat/tracing' is not allowed, must be one of either: main, release/*")
+	assert.NotContains(t, gittest.Tags(t), "0.2.0")
+}
+
+func TestTagAllowBranchesDirtyWorkingTree(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.TempFile(t, "tracing.go", "package tracing")
+	gittest.StageFile(t, "tracing.go")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-branches", "main"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "releasing from a dirty working tree is not allowed, uncommitted changes to: tracing.go")
+}
+
+func TestTagAllowBranchesRemoteMismatch(t *testing.T) {
+	log := `(main, origin/main) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.TempFile(t, "jaeger.go", "package tracing")
+	gittest.StageFile(t, "jaeger.go")
+	gittest.Commit(t, "feat: support exporting traces to jaeger")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-branches", "main"})
+	err := cmd.Execute()
+
+	var mismatch RemoteMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "main", mismatch.Branch)
+	assert.NotEqual(t, mismatch.Local, mismatch.Remote)
+}
+
+func TestTagAllowAuthorsNotAllowed(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithStagedFiles("export.go"))
+	gittest.CommitWithAuthor(t, "penguin", "penguin@dc.com", "feat(data): support data export from mongodb")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-authors", "batman@dc.com,robin"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "releasing a commit authored by 'penguin@dc.com' is not allowed, must be one of either: batman@dc.com, robin")
+	assert.Empty(t, gittest.Tags(t))
+}
+
+func TestTagAllowAuthorsGatesEveryPathBeforeTagging(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithFiles("src/ui/index.ts", "src/search/search.go"))
+	gittest.StageFile(t, "src/ui/index.ts")
+	gittest.CommitWithAuthor(t, "batman", "batman@dc.com", "feat(ui): initial search ui")
+	gittest.StageFile(t, "src/search/search.go")
+	gittest.CommitWithAuthor(t, "penguin", "penguin@dc.com", "feat(search): support searching by tags")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--allow-authors", "batman@dc.com", "src/ui", "src/search"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "releasing a commit authored by 'penguin@dc.com' is not allowed, must be one of either: batman@dc.com")
+	assert.Empty(t, gittest.Tags(t))
+}
+
+func TestTagCollisionWithRemoteTag(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.MustExec(t, "git tag 0.2.0")
+	gittest.MustExec(t, "git push origin 0.2.0")
+	gittest.MustExec(t, "git tag -d 0.2.0")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--on-collision", "fail"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "tags already exist and would clash: 0.2.0 (remote)")
+	assert.NotContains(t, gittest.Tags(t), "0.2.0")
+}
+
+func TestTagLightweight(t *testing.T) {
+	log := `(main, origin/main) feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: capture application traces`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--tag-type", "lightweight"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	objType := gittest.MustExec(t, "git cat-file -t 0.2.0")
+	assert.Equal(t, "commit", objType)
+}
+
+func TestTagTargetHead(t *testing.T) {
+	log := `feat: ensure logs are attached to spans during tracing
+(tag: 0.1.0) feat: support distributed tracing`
+	gittest.InitRepository(t,
+		gittest.WithLog(log),
+		gittest.WithCommittedFiles("VERSION"),
+		gittest.WithFileContent("VERSION", "0.1.0"),
+	)
+	head := gittest.LastCommit(t).Hash
+
+	execFile(t, "patch-version.sh", `#!/bin/bash
+echo -n $NSV_NEXT_TAG > VERSION`)
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--hook", "./patch-version.sh", "--tag-target", "head"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	logs := gittest.Log(t)
+	assert.Equal(t, "chore: patched files for release 0.2.0 [skip ci]", logs[0].Message)
+
+	tagged := gittest.MustExec(t, "git rev-list -n 1 0.2.0")
+	assert.Equal(t, head, tagged)
+}
+
+func TestTagUnsupportedTagType(t *testing.T) {
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--tag-type", "signed"})
+	err := cmd.Execute()
+
+	require.EqualError(t, err, "tag type 'signed' is not supported, must be one of either: annotated, lightweight")
+}
+
+func TestTagTargetForPath(t *testing.T) {
+	targets := []string{"head", "src/ui=patch"}
+
+	assert.Equal(t, "patch", tagTargetFor("src/ui", targets))
+	assert.Equal(t, "head", tagTargetFor("src/search", targets))
+	assert.Equal(t, "patch", tagTargetFor(".", nil))
+}
+
+func TestTagAtRef(t *testing.T) {
+	log := `(main, origin/main) feat: export traces using otlp
+fix: spans are not closed on error
+(tag: 0.1.0) feat: support distributed tracing`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	ref := gittest.MustExec(t, "git rev-parse HEAD~1")
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--ref", "HEAD~1"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, gittest.RemoteTags(t), "0.1.1")
+	assert.Equal(t, ref, gittest.MustExec(t, "git rev-list -n 1 0.1.1"))
+}
+
+func TestTagAtRefWithHook(t *testing.T) {
+	gittest.InitRepository(t)
+
+	cmd := tagCmd(&Options{Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--ref", "HEAD~1", "--hook", "./patch-version.sh"})
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	require.ErrorIs(t, err, errPatchWithRef)
+}
+
+func TestTagInteractiveFallsBackWithoutTerminal(t *testing.T) {
+	log := `feat: support exporting traces to jaeger
+(tag: 0.1.0) feat: support distributed tracing`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	cmd := tagCmd(&Options{In: strings.NewReader(""), Out: io.Discard, Err: io.Discard, Logger: noopLogger})
+	cmd.SetArgs([]string{"--interactive"})
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	tags := gittest.Tags(t)
+	assert.ElementsMatch(t, []string{"0.1.0", "0.2.0"}, tags)
+}
@@ -5,13 +5,66 @@ import (
 	"sync"
 )
 
+const defaultSkipPipelineTag = "[skip ci]"
+
 var (
 	so    sync.Once
 	ciEnv Environment
 )
 
+// Platform identifies a supported continuous integration (CI) platform
+type Platform string
+
+const (
+	None           Platform = ""
+	AzurePipelines Platform = "azure-pipelines"
+	Bitbucket      Platform = "bitbucket"
+	Buildkite      Platform = "buildkite"
+	CircleCI       Platform = "circleci"
+	CirrusCI       Platform = "cirrus-ci"
+	Codefresh      Platform = "codefresh"
+	Drone          Platform = "drone"
+	GitHubActions  Platform = "github-actions"
+	GitLabCI       Platform = "gitlab-ci"
+	Jenkins        Platform = "jenkins"
+	Semaphore      Platform = "semaphore"
+	TravisCI       Platform = "travis-ci"
+	Woodpecker     Platform = "woodpecker"
+)
+
 // Environment captures details of a continuous integration (CI) platform
 type Environment struct {
+	// Platform identifies the detected CI platform. It will be [None] if
+	// no CI platform could be detected
+	Platform Platform
+
+	// Branch contains the name of the branch that triggered the current
+	// build. Most CI platforms checkout a detached HEAD, making it impossible
+	// to resolve the branch from the repository alone. If the build was
+	// triggered by a pull request, this will be its source branch. It will
+	// be empty if the branch could not be resolved
+	Branch string
+
+	// BuildNumber contains the identifier of the current build, as
+	// assigned by the CI platform
+	BuildNumber string
+
+	// CommitSHA contains the full SHA of the commit being built
+	CommitSHA string
+
+	// DefaultBranch contains the name of the default branch of the
+	// repository, if exposed by the CI platform
+	DefaultBranch string
+
+	// IsPullRequest reports whether the build was triggered by a pull
+	// request (or merge request)
+	IsPullRequest bool
+
+	// PullRequest contains the number of the pull request (or merge request)
+	// that triggered the build. It will be empty if the build was not
+	// triggered by a pull request
+	PullRequest string
+
 	// SkipPipelineTag defines a tag that can be injected into the first
 	// line of a commit message to prevent the CI platform from running
 	// an unnecessary build.
@@ -28,6 +81,8 @@ type Environment struct {
 	// 	- [Buildkite] [skip ci]
 	// 	- [Jenkins] [ci skip]
 	// 	- [Bitbucket] [skip ci]
+	// 	- [Azure Pipelines] [skip ci]
+	// 	- [Woodpecker] [skip ci]
 	//
 	// [GitHub]: https://github.blog/changelog/2021-02-08-github-actions-skip-pull-request-and-push-workflows-with-skip-ci/
 	// [GitLab]: https://docs.gitlab.com/ee/ci/pipelines/#skip-a-pipeline
@@ -40,21 +95,19 @@ type Environment struct {
 	// [Buildkite]: https://buildkite.com/docs/pipelines/skipping#ignore-a-commit
 	// [Jenkins]: https://plugins.jenkins.io/scmskip/
 	// [Bitbucket]: https://confluence.atlassian.com/bbkb/how-to-skip-triggering-an-automatic-pipeline-build-using-skip-ci-label-1207188270.html
+	// [Azure Pipelines]: https://learn.microsoft.com/en-us/azure/devops/pipelines/repos/azure-repos-git#skipping-ci-for-individual-pushes
+	// [Woodpecker]: https://woodpecker-ci.org/docs/usage/pipeline-syntax#skip-commits
 	SkipPipelineTag string
-}
 
-func droneCI(res chan<- Environment) {
-	// https://docs.drone.io/pipeline/environment/reference/
-	if os.Getenv("DRONE") == "true" {
-		res <- Environment{SkipPipelineTag: "[CI SKIP]"}
-	}
+	// TargetBranch contains the name of the branch a pull request (or merge
+	// request) will be merged into. It will be empty if the build was not
+	// triggered by a pull request or the CI platform does not expose it
+	TargetBranch string
 }
 
-func jenkinsCI(res chan<- Environment) {
-	// https://www.jenkins.io/doc/book/pipeline/jenkinsfile/#using-environment-variables
-	if os.Getenv("JENKINS_URL") != "" {
-		res <- Environment{SkipPipelineTag: "[ci skip]"}
-	}
+// Detected reports whether a CI platform was detected
+func (e Environment) Detected() bool {
+	return e.Platform != None
 }
 
 // Detect will attempt to identify the current continuous integration (CI) platform
@@ -69,26 +122,38 @@ func Detect() Environment {
 }
 
 func detectCIFromEnv() Environment {
-	chn := make(chan Environment, 1)
+	results := make([]chan Environment, len(detectors))
 
-	detectors := sync.WaitGroup{}
-	detectors.Add(2)
+	wg := sync.WaitGroup{}
+	wg.Add(len(detectors))
 
-	goDetect := func(detectCI func(res chan<- Environment)) {
-		defer detectors.Done()
-		detectCI(chn)
+	for i, detectCI := range detectors {
+		results[i] = make(chan Environment, 1)
+		go func(res chan<- Environment) {
+			defer wg.Done()
+			detectCI(res)
+		}(results[i])
 	}
 
-	go goDetect(droneCI)
-	go goDetect(jenkinsCI)
+	wg.Wait()
+
+	// The first platform detected, in order of priority, wins
+	for _, res := range results {
+		close(res)
+		if env, detected := <-res; detected {
+			return env
+		}
+	}
 
-	detectors.Wait()
-	close(chn)
+	return Environment{SkipPipelineTag: defaultSkipPipelineTag}
+}
 
-	env := Environment{SkipPipelineTag: "[skip ci]"}
-	for denv := range chn {
-		env = denv
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
 	}
 
-	return env
+	return ""
 }
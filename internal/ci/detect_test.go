@@ -1,6 +1,8 @@
 package ci
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,9 +29,15 @@ func TestDetectSkipPipelineTag(t *testing.T) {
 			env:      []string{"JENKINS_URL", "http://jenkins"},
 			expected: "[ci skip]",
 		},
+		{
+			name:     "DroneBeforeJenkins",
+			env:      []string{"DRONE", "true", "JENKINS_URL", "http://jenkins"},
+			expected: "[CI SKIP]",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
 			for i := 0; i < len(tt.env); i += 2 {
 				t.Setenv(tt.env[i], tt.env[i+1])
 			}
@@ -39,3 +47,315 @@ func TestDetectSkipPipelineTag(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectEnvironment(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      []string
+		expected Environment
+	}{
+		{
+			name:     "None",
+			env:      []string{},
+			expected: Environment{SkipPipelineTag: "[skip ci]"},
+		},
+		{
+			name: "AzurePipelines",
+			env: []string{
+				"TF_BUILD", "True",
+				"BUILD_SOURCEBRANCH", "refs/heads/main",
+				"BUILD_BUILDID", "42",
+				"BUILD_SOURCEVERSION", "a2e1b2f",
+			},
+			expected: Environment{
+				Platform:        AzurePipelines,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "AzurePipelinesTag",
+			env: []string{
+				"TF_BUILD", "True",
+				"BUILD_SOURCEBRANCH", "refs/tags/v1.0.0",
+				"BUILD_BUILDID", "42",
+				"BUILD_SOURCEVERSION", "a2e1b2f",
+			},
+			expected: Environment{
+				Platform:        AzurePipelines,
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "Bitbucket",
+			env: []string{
+				"BITBUCKET_BUILD_NUMBER", "42",
+				"BITBUCKET_BRANCH", "feature/search",
+				"BITBUCKET_COMMIT", "a2e1b2f",
+				"BITBUCKET_PR_ID", "12",
+				"BITBUCKET_PR_DESTINATION_BRANCH", "main",
+			},
+			expected: Environment{
+				Platform:        Bitbucket,
+				Branch:          "feature/search",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				IsPullRequest:   true,
+				PullRequest:     "12",
+				SkipPipelineTag: "[skip ci]",
+				TargetBranch:    "main",
+			},
+		},
+		{
+			name: "Buildkite",
+			env: []string{
+				"BUILDKITE", "true",
+				"BUILDKITE_BRANCH", "main",
+				"BUILDKITE_BUILD_NUMBER", "42",
+				"BUILDKITE_COMMIT", "a2e1b2f",
+				"BUILDKITE_PIPELINE_DEFAULT_BRANCH", "main",
+				"BUILDKITE_PULL_REQUEST", "false",
+			},
+			expected: Environment{
+				Platform:        Buildkite,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				DefaultBranch:   "main",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "CircleCI",
+			env: []string{
+				"CIRCLECI", "true",
+				"CIRCLE_BRANCH", "feature/search",
+				"CIRCLE_BUILD_NUM", "42",
+				"CIRCLE_SHA1", "a2e1b2f",
+				"CIRCLE_PULL_REQUEST", "https://github.com/purpleclay/nsv/pull/12",
+			},
+			expected: Environment{
+				Platform:        CircleCI,
+				Branch:          "feature/search",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				IsPullRequest:   true,
+				PullRequest:     "12",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "Drone",
+			env: []string{
+				"DRONE", "true",
+				"DRONE_BRANCH", "main",
+				"DRONE_BUILD_NUMBER", "42",
+				"DRONE_COMMIT_SHA", "a2e1b2f",
+				"DRONE_REPO_BRANCH", "main",
+			},
+			expected: Environment{
+				Platform:        Drone,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				DefaultBranch:   "main",
+				SkipPipelineTag: "[CI SKIP]",
+			},
+		},
+		{
+			name: "GitHubActionsPush",
+			env: []string{
+				"GITHUB_ACTIONS", "true",
+				"GITHUB_EVENT_NAME", "push",
+				"GITHUB_HEAD_REF", "",
+				"GITHUB_REF", "refs/heads/main",
+				"GITHUB_REF_NAME", "main",
+				"GITHUB_REF_TYPE", "branch",
+				"GITHUB_RUN_NUMBER", "42",
+				"GITHUB_SHA", "a2e1b2f",
+				"GITHUB_EVENT_PATH", githubEventFile(t, `{"repository":{"default_branch":"main"}}`),
+			},
+			expected: Environment{
+				Platform:        GitHubActions,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				DefaultBranch:   "main",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "GitHubActionsPullRequest",
+			env: []string{
+				"GITHUB_ACTIONS", "true",
+				"GITHUB_EVENT_NAME", "pull_request",
+				"GITHUB_BASE_REF", "main",
+				"GITHUB_HEAD_REF", "feature/search",
+				"GITHUB_REF", "refs/pull/12/merge",
+				"GITHUB_REF_NAME", "12/merge",
+				"GITHUB_REF_TYPE", "branch",
+				"GITHUB_RUN_NUMBER", "42",
+				"GITHUB_SHA", "a2e1b2f",
+				"GITHUB_EVENT_PATH", "",
+			},
+			expected: Environment{
+				Platform:        GitHubActions,
+				Branch:          "feature/search",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				IsPullRequest:   true,
+				PullRequest:     "12",
+				SkipPipelineTag: "[skip ci]",
+				TargetBranch:    "main",
+			},
+		},
+		{
+			name: "GitHubActionsTag",
+			env: []string{
+				"GITHUB_ACTIONS", "true",
+				"GITHUB_EVENT_NAME", "push",
+				"GITHUB_HEAD_REF", "",
+				"GITHUB_REF", "refs/tags/0.1.0",
+				"GITHUB_REF_NAME", "0.1.0",
+				"GITHUB_REF_TYPE", "tag",
+				"GITHUB_RUN_NUMBER", "42",
+				"GITHUB_SHA", "a2e1b2f",
+				"GITHUB_EVENT_PATH", "",
+			},
+			expected: Environment{
+				Platform:        GitHubActions,
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "GitLabCIMergeRequest",
+			env: []string{
+				"GITLAB_CI", "true",
+				"CI_COMMIT_BRANCH", "",
+				"CI_COMMIT_SHA", "a2e1b2f",
+				"CI_DEFAULT_BRANCH", "main",
+				"CI_MERGE_REQUEST_IID", "12",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "feature/search",
+				"CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "main",
+				"CI_PIPELINE_IID", "42",
+			},
+			expected: Environment{
+				Platform:        GitLabCI,
+				Branch:          "feature/search",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				DefaultBranch:   "main",
+				IsPullRequest:   true,
+				PullRequest:     "12",
+				SkipPipelineTag: "[skip ci]",
+				TargetBranch:    "main",
+			},
+		},
+		{
+			name: "JenkinsGitPlugin",
+			env: []string{
+				"JENKINS_URL", "http://jenkins",
+				"BUILD_NUMBER", "42",
+				"GIT_BRANCH", "origin/main",
+				"GIT_COMMIT", "a2e1b2f",
+			},
+			expected: Environment{
+				Platform:        Jenkins,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				SkipPipelineTag: "[ci skip]",
+			},
+		},
+		{
+			name: "TravisCI",
+			env: []string{
+				"TRAVIS", "true",
+				"TRAVIS_BRANCH", "main",
+				"TRAVIS_BUILD_NUMBER", "42",
+				"TRAVIS_COMMIT", "a2e1b2f",
+				"TRAVIS_PULL_REQUEST", "false",
+			},
+			expected: Environment{
+				Platform:        TravisCI,
+				Branch:          "main",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				SkipPipelineTag: "[skip ci]",
+			},
+		},
+		{
+			name: "Woodpecker",
+			env: []string{
+				"CI", "woodpecker",
+				"CI_COMMIT_BRANCH", "main",
+				"CI_COMMIT_PULL_REQUEST", "12",
+				"CI_COMMIT_SHA", "a2e1b2f",
+				"CI_COMMIT_SOURCE_BRANCH", "feature/search",
+				"CI_COMMIT_TARGET_BRANCH", "main",
+				"CI_PIPELINE_EVENT", "pull_request",
+				"CI_PIPELINE_NUMBER", "42",
+				"CI_REPO_DEFAULT_BRANCH", "main",
+			},
+			expected: Environment{
+				Platform:        Woodpecker,
+				Branch:          "feature/search",
+				BuildNumber:     "42",
+				CommitSHA:       "a2e1b2f",
+				DefaultBranch:   "main",
+				IsPullRequest:   true,
+				PullRequest:     "12",
+				SkipPipelineTag: "[skip ci]",
+				TargetBranch:    "main",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			for i := 0; i < len(tt.env); i += 2 {
+				t.Setenv(tt.env[i], tt.env[i+1])
+			}
+
+			actual := detectCIFromEnv()
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func githubEventFile(t *testing.T, payload string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, os.WriteFile(path, []byte(payload), 0o644))
+	return path
+}
+
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+
+	// Ensure tests are not influenced by the CI platform they are running on
+	for _, key := range []string{
+		"BITBUCKET_BUILD_NUMBER",
+		"BUILDKITE",
+		"CF_BUILD_ID",
+		"CI",
+		"CIRCLECI",
+		"CIRRUS_CI",
+		"DRONE",
+		"GITHUB_ACTIONS",
+		"GITLAB_CI",
+		"JENKINS_URL",
+		"SEMAPHORE",
+		"TF_BUILD",
+		"TRAVIS",
+	} {
+		t.Setenv(key, "")
+	}
+}
@@ -0,0 +1,172 @@
+package ci
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// DotEnvFile is the name of the dotenv file written when running within
+// GitLab CI. It should be captured as a dotenv report artifact
+const DotEnvFile = "nsv.env"
+
+// Output captures the outcome of calculating the next semantic version
+// for a single path within a repository
+type Output struct {
+	// Path to the directory within the repository the version was
+	// calculated for
+	Path string
+
+	// Tag contains the next semantic version
+	Tag string
+
+	// PrevTag contains the previous semantic version
+	PrevTag string
+
+	// Increment contains the type of increment applied to the
+	// previous semantic version
+	Increment string
+}
+
+// WriteOutputs will write the provided outputs in a format native to the
+// detected CI platform, making them available to subsequent steps of a
+// pipeline. Each output is written using a set of keys scoped to its path,
+// along with a comma separated list of all tags:
+//
+//	tags=0.2.0,ui/0.1.1
+//	tag=0.2.0
+//	prev_tag=0.1.0
+//	increment=minor
+//	ui_tag=ui/0.1.1
+//	ui_prev_tag=ui/0.1.0
+//	ui_increment=patch
+//
+// Supported platforms and their corresponding outputs
+//   - [GitHub] step outputs and a job summary
+//   - [GitLab] a dotenv file, see [DotEnvFile], with keys converted to
+//     uppercase and prefixed with NSV_
+//   - [Buildkite] build meta-data, with keys prefixed with nsv_
+//
+// [GitHub]: https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-output-parameter
+// [GitLab]: https://docs.gitlab.com/ee/ci/yaml/artifacts_reports.html#artifactsreportsdotenv
+// [Buildkite]: https://buildkite.com/docs/pipelines/build-meta-data
+func WriteOutputs(env Environment, outs []Output) error {
+	if len(outs) == 0 {
+		return nil
+	}
+
+	switch env.Platform {
+	case GitHubActions:
+		return writeGitHubOutputs(outs)
+	case GitLabCI:
+		return writeGitLabDotEnv(outs)
+	case Buildkite:
+		return writeBuildkiteMetaData(outs)
+	default:
+		return nil
+	}
+}
+
+type keyValue struct {
+	Key   string
+	Value string
+}
+
+func outputPairs(outs []Output) []keyValue {
+	tags := make([]string, 0, len(outs))
+	for _, out := range outs {
+		tags = append(tags, out.Tag)
+	}
+
+	pairs := []keyValue{{Key: "tags", Value: strings.Join(tags, ",")}}
+	for _, out := range outs {
+		pairs = append(pairs,
+			keyValue{Key: outputKey(out.Path, "tag"), Value: out.Tag},
+			keyValue{Key: outputKey(out.Path, "prev_tag"), Value: out.PrevTag},
+			keyValue{Key: outputKey(out.Path, "increment"), Value: out.Increment},
+		)
+	}
+
+	return pairs
+}
+
+func outputKey(path, name string) string {
+	scope := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, path)
+
+	scope = strings.Trim(scope, "_")
+	if scope == "" {
+		return name
+	}
+
+	return scope + "_" + name
+}
+
+func writeGitHubOutputs(outs []Output) error {
+	var buf strings.Builder
+	for _, pair := range outputPairs(outs) {
+		fmt.Fprintf(&buf, "%s=%s\n", pair.Key, pair.Value)
+	}
+
+	if err := appendToFile(os.Getenv("GITHUB_OUTPUT"), buf.String()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString("### Next Semantic Version\n\n")
+	buf.WriteString("| Path | Tag | Previous Tag | Increment |\n")
+	buf.WriteString("|------|-----|--------------|-----------|\n")
+	for _, out := range outs {
+		fmt.Fprintf(&buf, "| `%s` | `%s` | `%s` | %s |\n", out.Path, out.Tag, out.PrevTag, out.Increment)
+	}
+
+	return appendToFile(os.Getenv("GITHUB_STEP_SUMMARY"), buf.String())
+}
+
+func writeGitLabDotEnv(outs []Output) error {
+	var buf strings.Builder
+	for _, pair := range outputPairs(outs) {
+		fmt.Fprintf(&buf, "NSV_%s=%s\n", strings.ToUpper(pair.Key), pair.Value)
+	}
+
+	return os.WriteFile(filepath.Join(os.Getenv("CI_PROJECT_DIR"), DotEnvFile), []byte(buf.String()), 0o644)
+}
+
+func writeBuildkiteMetaData(outs []Output) error {
+	agent, err := exec.LookPath("buildkite-agent")
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range outputPairs(outs) {
+		cmd := exec.Command(agent, "meta-data", "set", "nsv_"+pair.Key, pair.Value)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to set buildkite meta-data %s: %w: %s", pair.Key, err, out)
+		}
+	}
+
+	return nil
+}
+
+func appendToFile(path, content string) error {
+	// A missing path indicates the CI platform does not support this type of output
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	return err
+}
@@ -0,0 +1,85 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var outputs = []Output{
+	{Path: ".", Tag: "0.2.0", PrevTag: "0.1.0", Increment: "minor"},
+	{Path: "src/ui", Tag: "ui/0.1.1", PrevTag: "ui/0.1.0", Increment: "patch"},
+}
+
+func TestWriteOutputsGitHub(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "output")
+	summaryFile := filepath.Join(dir, "summary")
+	t.Setenv("GITHUB_OUTPUT", outputFile)
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+
+	err := WriteOutputs(Environment{Platform: GitHubActions}, outputs)
+	require.NoError(t, err)
+
+	assert.Equal(t, `tags=0.2.0,ui/0.1.1
+tag=0.2.0
+prev_tag=0.1.0
+increment=minor
+src_ui_tag=ui/0.1.1
+src_ui_prev_tag=ui/0.1.0
+src_ui_increment=patch
+`, readFile(t, outputFile))
+
+	assert.Equal(t, "### Next Semantic Version\n\n"+
+		"| Path | Tag | Previous Tag | Increment |\n"+
+		"|------|-----|--------------|-----------|\n"+
+		"| `.` | `0.2.0` | `0.1.0` | minor |\n"+
+		"| `src/ui` | `ui/0.1.1` | `ui/0.1.0` | patch |\n", readFile(t, summaryFile))
+}
+
+func TestWriteOutputsGitLab(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CI_PROJECT_DIR", dir)
+
+	err := WriteOutputs(Environment{Platform: GitLabCI}, outputs)
+	require.NoError(t, err)
+
+	assert.Equal(t, `NSV_TAGS=0.2.0,ui/0.1.1
+NSV_TAG=0.2.0
+NSV_PREV_TAG=0.1.0
+NSV_INCREMENT=minor
+NSV_SRC_UI_TAG=ui/0.1.1
+NSV_SRC_UI_PREV_TAG=ui/0.1.0
+NSV_SRC_UI_INCREMENT=patch
+`, readFile(t, filepath.Join(dir, DotEnvFile)))
+}
+
+func TestWriteOutputsBuildkite(t *testing.T) {
+	dir := t.TempDir()
+	metaData := filepath.Join(dir, "meta-data")
+
+	agent := `#!/bin/sh
+echo "$3=$4" >> ` + metaData
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "buildkite-agent"), []byte(agent), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	err := WriteOutputs(Environment{Platform: Buildkite}, outputs[:1])
+	require.NoError(t, err)
+
+	assert.Equal(t, `nsv_tags=0.2.0
+nsv_tag=0.2.0
+nsv_prev_tag=0.1.0
+nsv_increment=minor
+`, readFile(t, metaData))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,346 @@
+package ci
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	pullRefPrefix   = "refs/pull/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+type detector func(res chan<- Environment)
+
+// detectors are listed in order of priority, should the variables of more than one
+// platform be present. Woodpecker comes before drone, as it may expose drone variables
+// for compatibility, and jenkins is last, as its URL is often set on shared agents
+var detectors = []detector{
+	githubActions,
+	gitlabCI,
+	azurePipelines,
+	bitbucket,
+	buildkite,
+	circleCI,
+	cirrusCI,
+	codefresh,
+	semaphore,
+	travisCI,
+	woodpecker,
+	droneCI,
+	jenkinsCI,
+}
+
+func azurePipelines(res chan<- Environment) {
+	// https://learn.microsoft.com/en-us/azure/devops/pipelines/build/variables
+	if os.Getenv("TF_BUILD") != "True" {
+		return
+	}
+
+	pr := firstEnv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID")
+	res <- Environment{
+		Platform:        AzurePipelines,
+		Branch:          azureBranch(),
+		BuildNumber:     os.Getenv("BUILD_BUILDID"),
+		CommitSHA:       os.Getenv("BUILD_SOURCEVERSION"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), branchRefPrefix),
+	}
+}
+
+// azureBranch resolves the branch from its ref. A build triggered by a tag has no branch
+func azureBranch() string {
+	ref := firstEnv("SYSTEM_PULLREQUEST_SOURCEBRANCH", "BUILD_SOURCEBRANCH")
+	if strings.HasPrefix(ref, tagRefPrefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(ref, branchRefPrefix)
+}
+
+func bitbucket(res chan<- Environment) {
+	// https://support.atlassian.com/bitbucket-cloud/docs/variables-and-secrets/
+	if os.Getenv("BITBUCKET_BUILD_NUMBER") == "" {
+		return
+	}
+
+	pr := os.Getenv("BITBUCKET_PR_ID")
+	res <- Environment{
+		Platform:        Bitbucket,
+		Branch:          os.Getenv("BITBUCKET_BRANCH"),
+		BuildNumber:     os.Getenv("BITBUCKET_BUILD_NUMBER"),
+		CommitSHA:       os.Getenv("BITBUCKET_COMMIT"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH"),
+	}
+}
+
+func buildkite(res chan<- Environment) {
+	// https://buildkite.com/docs/pipelines/environment-variables
+	if os.Getenv("BUILDKITE") != "true" {
+		return
+	}
+
+	pr := falseAsEmpty(os.Getenv("BUILDKITE_PULL_REQUEST"))
+	res <- Environment{
+		Platform:        Buildkite,
+		Branch:          os.Getenv("BUILDKITE_BRANCH"),
+		BuildNumber:     os.Getenv("BUILDKITE_BUILD_NUMBER"),
+		CommitSHA:       os.Getenv("BUILDKITE_COMMIT"),
+		DefaultBranch:   os.Getenv("BUILDKITE_PIPELINE_DEFAULT_BRANCH"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("BUILDKITE_PULL_REQUEST_BASE_BRANCH"),
+	}
+}
+
+func circleCI(res chan<- Environment) {
+	// https://circleci.com/docs/variables/#built-in-environment-variables
+	if os.Getenv("CIRCLECI") != "true" {
+		return
+	}
+
+	pr := os.Getenv("CIRCLE_PR_NUMBER")
+	if prURL := os.Getenv("CIRCLE_PULL_REQUEST"); pr == "" && prURL != "" {
+		pr = path.Base(prURL)
+	}
+
+	res <- Environment{
+		Platform:        CircleCI,
+		Branch:          os.Getenv("CIRCLE_BRANCH"),
+		BuildNumber:     os.Getenv("CIRCLE_BUILD_NUM"),
+		CommitSHA:       os.Getenv("CIRCLE_SHA1"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+	}
+}
+
+func cirrusCI(res chan<- Environment) {
+	// https://cirrus-ci.org/guide/writing-tasks/#environment-variables
+	if os.Getenv("CIRRUS_CI") != "true" {
+		return
+	}
+
+	pr := os.Getenv("CIRRUS_PR")
+	res <- Environment{
+		Platform:        CirrusCI,
+		Branch:          os.Getenv("CIRRUS_BRANCH"),
+		BuildNumber:     os.Getenv("CIRRUS_BUILD_ID"),
+		CommitSHA:       os.Getenv("CIRRUS_CHANGE_IN_REPO"),
+		DefaultBranch:   os.Getenv("CIRRUS_DEFAULT_BRANCH"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("CIRRUS_BASE_BRANCH"),
+	}
+}
+
+func codefresh(res chan<- Environment) {
+	// https://codefresh.io/docs/docs/pipelines/variables/#system-variables
+	if os.Getenv("CF_BUILD_ID") == "" {
+		return
+	}
+
+	pr := os.Getenv("CF_PULL_REQUEST_NUMBER")
+	res <- Environment{
+		Platform:        Codefresh,
+		Branch:          os.Getenv("CF_BRANCH"),
+		BuildNumber:     os.Getenv("CF_BUILD_ID"),
+		CommitSHA:       os.Getenv("CF_REVISION"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("CF_PULL_REQUEST_TARGET"),
+	}
+}
+
+func droneCI(res chan<- Environment) {
+	// https://docs.drone.io/pipeline/environment/reference/
+	if os.Getenv("DRONE") != "true" {
+		return
+	}
+
+	pr := os.Getenv("DRONE_PULL_REQUEST")
+	res <- Environment{
+		Platform:        Drone,
+		Branch:          firstEnv("DRONE_SOURCE_BRANCH", "DRONE_BRANCH"),
+		BuildNumber:     os.Getenv("DRONE_BUILD_NUMBER"),
+		CommitSHA:       os.Getenv("DRONE_COMMIT_SHA"),
+		DefaultBranch:   os.Getenv("DRONE_REPO_BRANCH"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: "[CI SKIP]",
+		TargetBranch:    os.Getenv("DRONE_TARGET_BRANCH"),
+	}
+}
+
+type githubEvent struct {
+	Repository struct {
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+func githubActions(res chan<- Environment) {
+	// https://docs.github.com/en/actions/learn-github-actions/variables#default-environment-variables
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return
+	}
+
+	branch := os.Getenv("GITHUB_HEAD_REF")
+	if branch == "" && os.Getenv("GITHUB_REF_TYPE") == "branch" {
+		branch = os.Getenv("GITHUB_REF_NAME")
+	}
+
+	// A pull request is checked out using a ref of: refs/pull/<number>/merge
+	var pr string
+	if ref := os.Getenv("GITHUB_REF"); strings.HasPrefix(ref, pullRefPrefix) {
+		pr, _, _ = strings.Cut(strings.TrimPrefix(ref, pullRefPrefix), "/")
+	}
+
+	// The default branch is only available from the payload of the triggering event
+	var event githubEvent
+	if data, err := os.ReadFile(os.Getenv("GITHUB_EVENT_PATH")); err == nil {
+		_ = json.Unmarshal(data, &event)
+	}
+
+	res <- Environment{
+		Platform:        GitHubActions,
+		Branch:          branch,
+		BuildNumber:     os.Getenv("GITHUB_RUN_NUMBER"),
+		CommitSHA:       os.Getenv("GITHUB_SHA"),
+		DefaultBranch:   event.Repository.DefaultBranch,
+		IsPullRequest:   strings.HasPrefix(os.Getenv("GITHUB_EVENT_NAME"), "pull_request"),
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("GITHUB_BASE_REF"),
+	}
+}
+
+func gitlabCI(res chan<- Environment) {
+	// https://docs.gitlab.com/ee/ci/variables/predefined_variables.html
+	if os.Getenv("GITLAB_CI") != "true" {
+		return
+	}
+
+	mr := os.Getenv("CI_MERGE_REQUEST_IID")
+	res <- Environment{
+		Platform:        GitLabCI,
+		Branch:          firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH"),
+		BuildNumber:     os.Getenv("CI_PIPELINE_IID"),
+		CommitSHA:       os.Getenv("CI_COMMIT_SHA"),
+		DefaultBranch:   os.Getenv("CI_DEFAULT_BRANCH"),
+		IsPullRequest:   mr != "",
+		PullRequest:     mr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"),
+	}
+}
+
+func jenkinsCI(res chan<- Environment) {
+	// https://www.jenkins.io/doc/book/pipeline/jenkinsfile/#using-environment-variables
+	if os.Getenv("JENKINS_URL") == "" {
+		return
+	}
+
+	// GIT_BRANCH is set by the git plugin and will include the name of the remote
+	branch := firstEnv("CHANGE_BRANCH", "BRANCH_NAME", "GIT_BRANCH")
+
+	pr := os.Getenv("CHANGE_ID")
+	res <- Environment{
+		Platform:        Jenkins,
+		Branch:          strings.TrimPrefix(branch, "origin/"),
+		BuildNumber:     os.Getenv("BUILD_NUMBER"),
+		CommitSHA:       os.Getenv("GIT_COMMIT"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: "[ci skip]",
+		TargetBranch:    os.Getenv("CHANGE_TARGET"),
+	}
+}
+
+func semaphore(res chan<- Environment) {
+	// https://docs.semaphoreci.com/ci-cd-environment/environment-variables/
+	if os.Getenv("SEMAPHORE") != "true" {
+		return
+	}
+
+	pr := os.Getenv("SEMAPHORE_GIT_PR_NUMBER")
+	res <- Environment{
+		Platform:        Semaphore,
+		Branch:          firstEnv("SEMAPHORE_GIT_PR_BRANCH", "SEMAPHORE_GIT_BRANCH"),
+		BuildNumber:     os.Getenv("SEMAPHORE_WORKFLOW_NUMBER"),
+		CommitSHA:       os.Getenv("SEMAPHORE_GIT_SHA"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    prTarget(pr, os.Getenv("SEMAPHORE_GIT_BRANCH")),
+	}
+}
+
+func travisCI(res chan<- Environment) {
+	// https://docs.travis-ci.com/user/environment-variables/#default-environment-variables
+	if os.Getenv("TRAVIS") != "true" {
+		return
+	}
+
+	pr := falseAsEmpty(os.Getenv("TRAVIS_PULL_REQUEST"))
+	res <- Environment{
+		Platform:        TravisCI,
+		Branch:          firstEnv("TRAVIS_PULL_REQUEST_BRANCH", "TRAVIS_BRANCH"),
+		BuildNumber:     os.Getenv("TRAVIS_BUILD_NUMBER"),
+		CommitSHA:       os.Getenv("TRAVIS_COMMIT"),
+		IsPullRequest:   pr != "",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    prTarget(pr, os.Getenv("TRAVIS_BRANCH")),
+	}
+}
+
+func woodpecker(res chan<- Environment) {
+	// https://woodpecker-ci.org/docs/usage/environment#built-in-environment-variables
+	if os.Getenv("CI") != "woodpecker" {
+		return
+	}
+
+	pr := os.Getenv("CI_COMMIT_PULL_REQUEST")
+	res <- Environment{
+		Platform:        Woodpecker,
+		Branch:          firstEnv("CI_COMMIT_SOURCE_BRANCH", "CI_COMMIT_BRANCH"),
+		BuildNumber:     os.Getenv("CI_PIPELINE_NUMBER"),
+		CommitSHA:       os.Getenv("CI_COMMIT_SHA"),
+		DefaultBranch:   os.Getenv("CI_REPO_DEFAULT_BRANCH"),
+		IsPullRequest:   os.Getenv("CI_PIPELINE_EVENT") == "pull_request",
+		PullRequest:     pr,
+		SkipPipelineTag: defaultSkipPipelineTag,
+		TargetBranch:    prTarget(pr, os.Getenv("CI_COMMIT_TARGET_BRANCH")),
+	}
+}
+
+// Some CI platforms only expose the target branch of a pull request through
+// a variable that otherwise contains the branch being built
+func prTarget(pr, branch string) string {
+	if pr == "" {
+		return ""
+	}
+
+	return branch
+}
+
+// Some CI platforms explicitly set a pull request variable to false when
+// a build was not triggered by a pull request
+func falseAsEmpty(value string) string {
+	if value == "false" {
+		return ""
+	}
+
+	return value
+}
@@ -0,0 +1,115 @@
+package nsv
+
+import "slices"
+
+// ExcludedReason is the reason given for any commit excluded through an [Adjustment]
+const ExcludedReason = "excluded"
+
+// Adjustment overrides how the next semantic version is calculated, typically
+// while reviewing a release
+type Adjustment struct {
+	// Exclude contains the index of each commit within the log that should take
+	// no part in detecting the increment
+	Exclude []int
+
+	// Increment overrides the detected increment. The detected increment is
+	// used if set to NoIncrement
+	Increment Increment
+}
+
+// Adjust recalculates the next semantic version after applying an adjustment to its
+// log. Adjustments should always be applied to the version originally calculated by
+// [NextVersion], as each one replaces the last. Any files are patched again, so any
+// changes made while calculating the original version should be discarded first. A
+// nil version is returned if the adjusted log no longer warrants a release
+func Adjust(repo Repository, next *Next, adj Adjustment, opts Options) (*Next, error) {
+	ignored := slices.Clone(next.Ignored)
+	for _, idx := range adj.Exclude {
+		ignored = append(ignored, Ignored{Index: idx, Reason: ExcludedReason})
+	}
+	active, indexes := activeCommits(next.Log, next.Reverts, ignored)
+
+	cmd, inc, match, err := detectIncrement(next.Log, active, indexes, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if adj.Increment != NoIncrement {
+		opts.Logger.Info("overriding detected increment", "detected", inc.String(), "increment", adj.Increment.String())
+		inc = adj.Increment
+		cmd.Set = ""
+
+		// Without a triggering commit, the forced increment is attributed to the
+		// latest commit that is still active
+		if match.Index == noMatchIdx {
+			if len(indexes) == 0 {
+				opts.Logger.Info("no active commits remain to release after adjustment", "excluded", len(adj.Exclude))
+				return nil, nil
+			}
+			match = Match{Index: indexes[0]}
+		}
+	}
+
+	if inc == NoIncrement && cmd.Set == "" {
+		opts.Logger.Info("no next semantic version detected after adjustment", "excluded", len(adj.Exclude))
+		return nil, nil
+	}
+
+	ctx, err := resolveContext(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = ctx.withLanguage(opts)
+
+	tags, err := repo.Tags(opts.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous tag will not exist if it was defaulted to the first version
+	first := !slices.Contains(tags, next.PrevTag)
+	ver, err := ctx.parseTag(next.PrevTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Prerelease != "" && !ver.PrereleaseWithLabel(cmd.Prerelease) {
+		if preTag := ctx.latestTag(tags, cmd.Prerelease); preTag != "" {
+			ver, _ = ctx.parseTag(preTag)
+		}
+	}
+
+	nextTag, inc, err := resolveNextTag(ver, first, inc, cmd, opts)
+	if err != nil {
+		return nil, err
+	}
+	nextVer := nextTag.Format(opts.VersionFormat)
+
+	if opts.OnCollision != "" {
+		if nextVer, err = resolveCollision(repo, nextVer, opts); err != nil {
+			return nil, err
+		}
+
+		if nextVer == "" {
+			return nil, nil
+		}
+	}
+	opts.Logger.Info("adjusted next semantic version", "next", nextVer, "prev", next.PrevTag, "increment", inc.String())
+
+	diffs, err := patchRelease(repo, next.LogDir, next.PrevTag, nextVer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Next{
+		Diffs:     diffs,
+		Ignored:   ignored,
+		Increment: inc,
+		Log:       next.Log,
+		LogDir:    next.LogDir,
+		Match:     match,
+		PrevTag:   next.PrevTag,
+		Reverts:   next.Reverts,
+		Tag:       nextVer,
+	}, nil
+}
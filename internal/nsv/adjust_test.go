@@ -0,0 +1,99 @@
+package nsv_test
+
+import (
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustExcludeCommits(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+	repo.Commit("feat: support pagination of search results", "search.go")
+
+	opts := nsv.Options{Logger: noopLogger}
+	next, err := nsv.NextVersion(repo, opts)
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", next.Tag)
+
+	adjusted, err := nsv.Adjust(repo, next, nsv.Adjustment{Exclude: []int{0}}, opts)
+	require.NoError(t, err)
+	require.NotNil(t, adjusted)
+
+	assert.Equal(t, "0.1.1", adjusted.Tag)
+	assert.Equal(t, nsv.PatchIncrement, adjusted.Increment)
+	assert.Equal(t, 1, adjusted.Match.Index)
+	assert.Equal(t, []nsv.Ignored{{Index: 0, Reason: nsv.ExcludedReason}}, adjusted.Ignored)
+}
+
+func TestAdjustExcludeAllCommits(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	opts := nsv.Options{Logger: noopLogger}
+	next, err := nsv.NextVersion(repo, opts)
+	require.NoError(t, err)
+
+	adjusted, err := nsv.Adjust(repo, next, nsv.Adjustment{Exclude: []int{0}}, opts)
+	require.NoError(t, err)
+	assert.Nil(t, adjusted)
+}
+
+func TestAdjustOverrideIncrement(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("v1.2.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	opts := nsv.Options{Logger: noopLogger}
+	next, err := nsv.NextVersion(repo, opts)
+	require.NoError(t, err)
+
+	adjusted, err := nsv.Adjust(repo, next, nsv.Adjustment{Increment: nsv.MajorIncrement}, opts)
+	require.NoError(t, err)
+	require.NotNil(t, adjusted)
+
+	assert.Equal(t, "v2.0.0", adjusted.Tag)
+	assert.Equal(t, "v1.2.0", adjusted.PrevTag)
+}
+
+func TestAdjustFirstRelease(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	opts := nsv.Options{Logger: noopLogger, InitialVersion: "1.0.0"}
+	next, err := nsv.NextVersion(repo, opts)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", next.Tag)
+
+	adjusted, err := nsv.Adjust(repo, next, nsv.Adjustment{Exclude: []int{1}}, opts)
+	require.NoError(t, err)
+	require.NotNil(t, adjusted)
+	assert.Equal(t, "1.0.0", adjusted.Tag)
+}
+
+func TestAdjustOverrideIncrementAfterExcludingTrigger(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("docs: document search", "README.md")
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	opts := nsv.Options{Logger: noopLogger}
+	next, err := nsv.NextVersion(repo, opts)
+	require.NoError(t, err)
+
+	adjusted, err := nsv.Adjust(repo, next, nsv.Adjustment{Exclude: []int{0}, Increment: nsv.MinorIncrement}, opts)
+	require.NoError(t, err)
+	require.NotNil(t, adjusted)
+
+	assert.Equal(t, "0.2.0", adjusted.Tag)
+	assert.Equal(t, nsv.Match{Index: 1}, adjusted.Match)
+}
@@ -0,0 +1,159 @@
+package nsv
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	BoundaryCommit = "commit"
+	BoundaryMerge  = "merge"
+
+	mergePrefix = "Merge "
+)
+
+var Boundaries = []string{BoundaryCommit, BoundaryMerge}
+
+type UnsupportedBoundaryError struct {
+	Boundary string
+}
+
+func (e UnsupportedBoundaryError) Error() string {
+	return fmt.Sprintf("boundary '%s' is not supported, must be one of either: %s",
+		e.Boundary, strings.Join(Boundaries, ", "))
+}
+
+// CheckBoundary ensures the release boundary used when backfilling tags is supported
+func CheckBoundary(boundary string) error {
+	for _, b := range Boundaries {
+		if b == boundary {
+			return nil
+		}
+	}
+
+	return UnsupportedBoundaryError{Boundary: boundary}
+}
+
+// Backfill walks the history of a path, from the latest tag, and calculates every
+// semantic version that would have been released along the way. A release is cut
+// at each boundary where an increment is detected. With a commit boundary, that is
+// any commit. With a merge boundary, only merge commits are considered, with each
+// release containing all commits since the previous one. Versions are returned in
+// the order they would have been released, with each tag pointing to the first
+// commit within its log
+func Backfill(repo Repository, boundary string, opts Options) ([]*Next, error) {
+	if err := CheckBoundary(boundary); err != nil {
+		return nil, err
+	}
+
+	if err := checkAndHealRepository(repo, opts); err != nil {
+		return nil, err
+	}
+
+	ctx, err := resolveContext(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = ctx.withLanguage(opts)
+
+	_, ltag, err := resolveLatestTag(repo, ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// A merge boundary relies on merge commits, which are only kept by a full history
+	retrieveLog := repo.Log
+	if boundary == BoundaryMerge {
+		retrieveLog = repo.MergeLog
+	}
+
+	log, err := retrieveLog(opts.Ref, ltag, ctx.LogPath)
+	if err != nil {
+		return nil, err
+	}
+	opts.Logger.Info("retrieved git log", "commits", len(log), "log_path", ctx.LogPath, "from", ltag)
+
+	rules, err := ignoreRules(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authors are retrieved once for the entire history, rather than per release
+	var authors map[string]string
+	if len(rules.Authors) > 0 && len(log) > 0 {
+		if authors, err = repo.Authors(opts.Ref, ltag, ctx.LogPath); err != nil {
+			return nil, err
+		}
+	}
+
+	first := ltag == ""
+	if first {
+		ltag = firstVersion(ctx, opts)
+		opts.Logger.Debug("defaulting to first semantic version", "tag", ltag)
+	}
+	ver, _ := ctx.parseTag(ltag)
+	prev := ltag
+
+	var vers []*Next
+
+	// The log is ordered from newest to oldest, so walk it backwards. Each release
+	// contains the commits between the previous boundary and the current one
+	end := len(log)
+	for i := len(log) - 1; i >= 0; i-- {
+		if boundary == BoundaryMerge && !strings.HasPrefix(log[i].Message, mergePrefix) {
+			continue
+		}
+
+		group := log[i:end]
+		reverts := DetectReverts(group)
+		ignored := rules.DetectIgnored(group, authors)
+		active, indexes := activeCommits(group, reverts, ignored)
+
+		cmd, inc, match, err := detectIncrement(group, active, indexes, opts)
+		if err != nil {
+			return nil, err
+		}
+		if inc == NoIncrement && cmd.Set == "" {
+			continue
+		}
+
+		nextTag, inc, err := resolveNextTag(ver, first, inc, cmd, opts)
+		if err != nil {
+			return nil, err
+		}
+		nextVer := nextTag.Format(opts.VersionFormat)
+
+		opts.Logger.Info("backfilled semantic version",
+			"next",
+			nextVer,
+			"prev",
+			prev,
+			"increment",
+			inc.String(),
+			"hash",
+			group[0].AbbrevHash,
+		)
+
+		vers = append(vers, &Next{
+			Ignored:   ignored,
+			Increment: inc,
+			Log:       group,
+			LogDir:    ctx.LogPath,
+			Match:     match,
+			PrevTag:   prev,
+			Reverts:   reverts,
+			Tag:       nextVer,
+		})
+
+		ver = nextTag
+		prev = nextVer
+		first = false
+		end = i
+	}
+
+	if end > 0 {
+		opts.Logger.Info("commits left unreleased after the last boundary", "commits", end)
+	}
+
+	return vers, nil
+}
@@ -0,0 +1,139 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type backfilled struct {
+	Tag     string
+	PrevTag string
+	Hash    string
+}
+
+func backfilledTags(vers []*nsv.Next) []backfilled {
+	tags := make([]backfilled, 0, len(vers))
+	for _, ver := range vers {
+		tags = append(tags, backfilled{Tag: ver.Tag, PrevTag: ver.PrevTag, Hash: ver.Log[0].Hash})
+	}
+	return tags
+}
+
+func TestBackfillCommitBoundary(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	feat, _ := repo.Commit("feat: initial search support", "search.go")
+	repo.Commit("docs: document search", "README.md")
+	fix, _ := repo.Commit("fix: search results not sorted", "search.go")
+	tags, _ := repo.Commit("feat: support searching by tags", "search.go")
+	repo.Commit("ci: cache go modules", ".github/workflows/ci.yml")
+
+	vers, err := nsv.Backfill(repo, nsv.BoundaryCommit, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, []backfilled{
+		{Tag: "0.1.0", PrevTag: "0.0.0", Hash: feat},
+		{Tag: "0.1.1", PrevTag: "0.1.0", Hash: fix},
+		{Tag: "0.2.0", PrevTag: "0.1.1", Hash: tags},
+	}, backfilledTags(vers))
+
+	// Commits without an increment are released with the next boundary
+	require.Len(t, vers[1].Log, 2)
+	assert.Equal(t, 0, vers[1].Match.Index)
+}
+
+func TestBackfillMergeBoundary(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	first, _ := repo.Commit("Merge pull request #1 from purpleclay/search", "search.go")
+	repo.Commit("fix: search results not sorted", "search.go")
+	repo.Commit("feat: support pagination of search results", "search.go")
+	second, _ := repo.Commit("Merge pull request #2 from purpleclay/pagination", "search.go")
+	repo.Commit("docs: document pagination", "README.md")
+	repo.Commit("Merge pull request #3 from purpleclay/docs", "README.md")
+	repo.Commit("fix: pagination off by one", "search.go")
+
+	vers, err := nsv.Backfill(repo, nsv.BoundaryMerge, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, []backfilled{
+		{Tag: "0.1.0", PrevTag: "0.0.0", Hash: first},
+		{Tag: "0.2.0", PrevTag: "0.1.0", Hash: second},
+	}, backfilledTags(vers))
+	assert.Len(t, vers[1].Log, 3)
+}
+
+func TestBackfillFromLatestTag(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("v0.1.0", "", ""))
+	fix, _ := repo.Commit("fix: search results not sorted", "search.go")
+	feat, _ := repo.Commit("feat: support pagination of search results", "search.go")
+
+	vers, err := nsv.Backfill(repo, nsv.BoundaryCommit, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, []backfilled{
+		{Tag: "v0.1.1", PrevTag: "v0.1.0", Hash: fix},
+		{Tag: "v0.2.0", PrevTag: "v0.1.1", Hash: feat},
+	}, backfilledTags(vers))
+}
+
+func TestBackfillInitialVersion(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	vers, err := nsv.Backfill(repo, nsv.BoundaryCommit, nsv.Options{Logger: noopLogger, InitialVersion: "1.0.0"})
+	require.NoError(t, err)
+
+	require.Len(t, vers, 2)
+	assert.Equal(t, "1.0.0", vers[0].Tag)
+	assert.Equal(t, "1.0.1", vers[1].Tag)
+}
+
+func TestBackfillWithPath(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat(ui): initial search ui", "src/ui/index.ts")
+	repo.Commit("feat(search): support searching by tags", "src/search/search.go")
+	repo.Commit("fix(ui): dark mode toggle not persisted", "src/ui/theme.ts")
+
+	vers, err := nsv.Backfill(repo, nsv.BoundaryCommit, nsv.Options{Logger: noopLogger, Path: "src/ui"})
+	require.NoError(t, err)
+
+	require.Len(t, vers, 2)
+	assert.Equal(t, "ui/0.1.0", vers[0].Tag)
+	assert.Equal(t, "ui/0.1.1", vers[1].Tag)
+}
+
+func TestBackfillUnsupportedBoundary(t *testing.T) {
+	_, err := nsv.Backfill(nsv.NewMemoryRepository(), "release", nsv.Options{Logger: noopLogger})
+	require.EqualError(t, err, "boundary 'release' is not supported, must be one of either: commit, merge")
+}
+
+func TestBackfillMergeBoundaryNoFastForward(t *testing.T) {
+	gittest.InitRepository(t)
+	gittest.MustExec(t, "git checkout -b feat/search")
+	gittest.TempFile(t, "search.go", "package search")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "feat: initial search support")
+	gittest.TempFile(t, "search.go", "package search\n\nfunc Search() {}")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "fix: search results not sorted")
+	gittest.MustExec(t, "git checkout "+gittest.DefaultBranch)
+	gittest.MustExec(t, "git merge --no-ff feat/search -m 'Merge pull request #1 from purpleclay/search'")
+	merge := gittest.LastCommit(t).Hash
+
+	gitc, _ := git.NewClient()
+	vers, err := nsv.Backfill(nsv.NewGitRepository(gitc), nsv.BoundaryMerge, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, []backfilled{
+		{Tag: "0.1.0", PrevTag: "0.0.0", Hash: merge},
+	}, backfilledTags(vers))
+	assert.Len(t, vers[0].Log, 4)
+}
@@ -0,0 +1,87 @@
+package nsv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+type InvalidVersionError struct {
+	Name    string
+	Version string
+}
+
+func (e InvalidVersionError) Error() string {
+	return fmt.Sprintf("%s '%s' is not a valid semantic version", e.Name, e.Version)
+}
+
+type SinceTagError struct {
+	Tag    string
+	Reason string
+}
+
+func (e SinceTagError) Error() string {
+	return fmt.Sprintf("since tag '%s' cannot be used, %s", e.Tag, e.Reason)
+}
+
+type SetVersionError struct {
+	Version string
+	Prev    string
+}
+
+func (e SetVersionError) Error() string {
+	return fmt.Sprintf("version '%s' set by nsv command must be greater than the latest tag %s", e.Version, e.Prev)
+}
+
+// CheckVersion ensures a named version is a valid semantic version, optionally
+// prefixed with a v. An empty version is valid
+func CheckVersion(name, version string) error {
+	if version == "" {
+		return nil
+	}
+
+	if _, err := semver.StrictNewVersion(strings.TrimPrefix(version, string(vPrefix))); err != nil {
+		return InvalidVersionError{Name: name, Version: version}
+	}
+	return nil
+}
+
+// setVersion pins the next version to the one provided by an nsv set command. The
+// increment is derived from the difference between the previous and pinned version
+func setVersion(ver Tag, set string) (Tag, Increment, error) {
+	prev, err := semver.StrictNewVersion(ver.SemVer)
+	if err != nil {
+		return Tag{}, NoIncrement, err
+	}
+
+	pinned := semver.MustParse(strings.TrimPrefix(set, string(vPrefix)))
+	if !pinned.GreaterThan(prev) {
+		return Tag{}, NoIncrement, SetVersionError{Version: set, Prev: ver.Raw}
+	}
+
+	// A version that only differs by prerelease is still released as a patch
+	inc := max(incrementBetween(prev, pinned), PatchIncrement)
+
+	return ver.Bump(pinned.String()), inc, nil
+}
+
+// floorVersion raises the next version to a minimum version, if it would otherwise
+// fall below it. Any prerelease label requested through an nsv command is kept
+func floorVersion(next Tag, minVersion string, cmd Command) (Tag, error) {
+	semv, err := semver.StrictNewVersion(next.SemVer)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	floor := semver.MustParse(strings.TrimPrefix(minVersion, string(vPrefix)))
+	if !semv.LessThan(floor) {
+		return next, nil
+	}
+
+	if cmd.Prerelease != "" && floor.Prerelease() == "" {
+		*floor, _ = floor.SetPrerelease(cmd.Prerelease + ".1")
+	}
+
+	return next.Bump(floor.String()), nil
+}
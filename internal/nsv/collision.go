@@ -0,0 +1,188 @@
+package nsv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	CollisionFail = "fail"
+	CollisionSkip = "skip"
+	CollisionBump = "bump"
+
+	maxCollisionBump = 100
+)
+
+var CollisionStrategies = []string{CollisionFail, CollisionSkip, CollisionBump}
+
+type UnsupportedCollisionStrategyError struct {
+	Strategy string
+}
+
+func (e UnsupportedCollisionStrategyError) Error() string {
+	return fmt.Sprintf("collision strategy '%s' is not supported, must be one of either: %s",
+		e.Strategy, strings.Join(CollisionStrategies, ", "))
+}
+
+// TagCollision identifies a tag that already exists, either locally, on the
+// push remote, or both
+type TagCollision struct {
+	Tag    string
+	Local  bool
+	Remote bool
+}
+
+func (c TagCollision) String() string {
+	var where []string
+	if c.Local {
+		where = append(where, "local")
+	}
+	if c.Remote {
+		where = append(where, "remote")
+	}
+
+	return fmt.Sprintf("%s (%s)", c.Tag, strings.Join(where, ", "))
+}
+
+type TagCollisionError struct {
+	Collisions []TagCollision
+	Reason     string
+}
+
+func (e TagCollisionError) Error() string {
+	tags := make([]string, 0, len(e.Collisions))
+	for _, collision := range e.Collisions {
+		tags = append(tags, collision.String())
+	}
+
+	msg := "tags already exist and would clash: " + strings.Join(tags, ", ")
+	if e.Reason != "" {
+		msg += ", " + e.Reason
+	}
+	return msg
+}
+
+// CheckCollisionStrategy ensures the collision strategy is supported
+func CheckCollisionStrategy(strategy string) error {
+	for _, s := range CollisionStrategies {
+		if s == strategy {
+			return nil
+		}
+	}
+
+	return UnsupportedCollisionStrategyError{Strategy: strategy}
+}
+
+// tagIndex contains all existing tags, both locally and on the push remote
+type tagIndex struct {
+	local  map[string]struct{}
+	remote map[string]struct{}
+}
+
+// existingTags indexes all local and remote tags. Remote tags provided through the
+// options are used as is, avoiding a round trip to the push remote for every path
+func existingTags(repo Repository, opts Options) (tagIndex, error) {
+	local, err := repo.Tags("")
+	if err != nil {
+		return tagIndex{}, err
+	}
+
+	// Without a push remote, there is nothing to clash with
+	remote := opts.RemoteTags
+	if remote == nil {
+		if remote, err = repo.RemoteTags(); err != nil {
+			return tagIndex{}, err
+		}
+	}
+
+	idx := tagIndex{local: map[string]struct{}{}, remote: map[string]struct{}{}}
+	for _, tag := range local {
+		idx.local[tag] = struct{}{}
+	}
+
+	for _, tag := range remote {
+		idx.remote[tag] = struct{}{}
+	}
+
+	return idx, nil
+}
+
+func (i tagIndex) collisions(tags ...string) []TagCollision {
+	var collisions []TagCollision
+	for _, tag := range tags {
+		_, local := i.local[tag]
+		_, remote := i.remote[tag]
+		if local || remote {
+			collisions = append(collisions, TagCollision{Tag: tag, Local: local, Remote: remote})
+		}
+	}
+
+	return collisions
+}
+
+// resolveCollision applies the collision strategy to the next tag. An empty tag
+// is returned if the tag should be skipped
+func resolveCollision(repo Repository, tag string, opts Options) (string, error) {
+	existing, err := existingTags(repo, opts)
+	if err != nil {
+		return "", err
+	}
+
+	collisions := existing.collisions(tag)
+	if len(collisions) == 0 {
+		return tag, nil
+	}
+
+	switch opts.OnCollision {
+	case CollisionSkip:
+		opts.Logger.Warn("skipping release as tag already exists", "tag", collisions[0].String())
+		return "", nil
+	case CollisionBump:
+		return bumpPastCollision(existing, tag, collisions, opts)
+	default:
+		return "", TagCollisionError{Collisions: collisions}
+	}
+}
+
+func bumpPastCollision(existing tagIndex, tag string, collisions []TagCollision, opts Options) (string, error) {
+	// A custom format, such as ui@1.0.0, cannot be reliably parsed and rebuilt
+	ver, err := ParseTag(tag)
+	if err != nil {
+		return "", TagCollisionError{
+			Collisions: collisions,
+			Reason:     "cannot bump past collision for this format, it is not a semantic version",
+		}
+	}
+
+	if !ver.Prerelease() {
+		return "", TagCollisionError{
+			Collisions: collisions,
+			Reason:     "cannot bump past collision for this format, only a prerelease counter can be bumped",
+		}
+	}
+
+	label, counter, _ := strings.Cut(ver.Pre, ".")
+	n, err := strconv.Atoi(counter)
+	if err != nil {
+		return "", TagCollisionError{
+			Collisions: collisions,
+			Reason:     "prerelease " + ver.Pre + " does not have a counter to bump",
+		}
+	}
+
+	core, _, _ := strings.Cut(ver.SemVer, "-")
+	for i := 0; i < maxCollisionBump; i++ {
+		n++
+		bumped := ver.Bump(fmt.Sprintf("%s-%s.%d", core, label, n)).Raw
+
+		clashes := existing.collisions(bumped)
+		if len(clashes) == 0 {
+			opts.Logger.Warn("bumped prerelease counter as tag already exists", "tag", tag, "bumped", bumped)
+			return bumped, nil
+		}
+		collisions = append(collisions, clashes...)
+	}
+
+	return "", TagCollisionError{Collisions: collisions}
+}
@@ -0,0 +1,102 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func remoteOnlyTags(t *testing.T, tags ...string) {
+	t.Helper()
+
+	for _, tag := range tags {
+		gittest.MustExec(t, "git tag "+tag)
+		gittest.MustExec(t, "git push origin "+tag)
+		gittest.MustExec(t, "git tag -d "+tag)
+	}
+}
+
+func TestNextVersionCollisionFail(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+> (tag: 0.2.0) feat: use the elastic scroll api to page results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "0.3.0")
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, OnCollision: nsv.CollisionFail})
+	require.EqualError(t, err, "tags already exist and would clash: 0.3.0 (remote)")
+}
+
+func TestNextVersionCollisionSkip(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+> (tag: 0.2.0) feat: use the elastic scroll api to page results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "0.3.0")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, OnCollision: nsv.CollisionSkip})
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func TestNextVersionCollisionBumpsPrerelease(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+nsv:pre~alpha
+> (tag: 0.2.0) feat: use the elastic scroll api to page results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "0.3.0-alpha.1", "0.3.0-alpha.2")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, OnCollision: nsv.CollisionBump})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.3.0-alpha.3", next.Tag)
+}
+
+func TestNextVersionCollisionBumpRequiresPrerelease(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+> (tag: 0.2.0) feat: use the elastic scroll api to page results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "0.3.0")
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, OnCollision: nsv.CollisionBump})
+	require.EqualError(t, err, "tags already exist and would clash: 0.3.0 (remote), cannot bump past collision for this format, only a prerelease counter can be bumped")
+}
+
+func TestNextVersionCollisionBumpCustomFormat(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+nsv:pre~alpha`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "ui@0.1.0-alpha.1")
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{
+		Logger:        noopLogger,
+		OnCollision:   nsv.CollisionBump,
+		VersionFormat: "ui@{{.SemVer}}",
+	})
+	require.EqualError(t, err, "tags already exist and would clash: ui@0.1.0-alpha.1 (remote), "+
+		"cannot bump past collision for this format, it is not a semantic version")
+}
+
+func TestNextVersionCollisionUsesProvidedRemoteTags(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+> (tag: 0.2.0) feat: use the elastic scroll api to page results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	remoteOnlyTags(t, "0.3.0")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{
+		Logger:      noopLogger,
+		OnCollision: nsv.CollisionFail,
+		RemoteTags:  []string{},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.3.0", next.Tag)
+}
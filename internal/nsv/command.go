@@ -3,6 +3,7 @@ package nsv
 import (
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/purpleclay/chomp"
 	git "github.com/purpleclay/gitz"
 )
@@ -19,11 +20,13 @@ const (
 	preAlpha    = "alpha"
 	preBeta     = "beta"
 	preRc       = "rc"
+	setCmd      = "set"
 )
 
 type Command struct {
 	Force      Increment
 	Prerelease string
+	Set        string
 }
 
 func DetectCommand(log []git.LogEntry) (Command, Match) {
@@ -51,6 +54,8 @@ func DetectCommand(log []git.LogEntry) (Command, Match) {
 				command.Force = chompForce(cmd)
 			} else if strings.HasPrefix(cmd, preCmd) {
 				command.Prerelease = chompPre(cmd)
+			} else if strings.HasPrefix(cmd, setCmd) {
+				command.Set = chompSet(cmd)
 			}
 		}
 
@@ -111,3 +116,16 @@ func chompPre(cmd string) string {
 
 	return out[1]
 }
+
+func chompSet(cmd string) string {
+	rem, _, err := chomp.Pair(chomp.Tag(setCmd), chomp.Tag(sep))(cmd)
+	if err != nil {
+		return ""
+	}
+
+	if _, err := semver.StrictNewVersion(strings.TrimPrefix(rem, string(vPrefix))); err != nil {
+		return ""
+	}
+
+	return rem
+}
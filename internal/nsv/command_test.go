@@ -135,6 +135,45 @@ nsv:%s`, tt.command),
 	}
 }
 
+func TestDetectCommandSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		command string
+		set     string
+	}{
+		{
+			name:    "Version",
+			command: "set~2.0.0",
+			set:     "2.0.0",
+		},
+		{
+			name:    "VPrefixedVersion",
+			command: "set~v1.4.0-rc.1",
+			set:     "v1.4.0-rc.1",
+		},
+		{
+			name:    "InvalidVersion",
+			command: "set~2.0",
+			set:     "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd, _ := nsv.DetectCommand([]git.LogEntry{
+				{
+					Message: fmt.Sprintf(`correct the version after a botched release
+nsv:%s`, tt.command),
+				},
+			})
+			require.Equal(t, tt.set, cmd.Set, "failed to match set version")
+		})
+	}
+}
+
 func TestDetectMultipleCommands(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,208 @@
+package nsv
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+// OtherType groups any commits that do not follow the conventional commit format
+const OtherType = "other"
+
+// leading conventional commit types, listed before all others when comparing releases
+var leadingTypes = []string{"feat", "fix"}
+
+type NoReleasesError struct {
+	Prefix string
+}
+
+func (e NoReleasesError) Error() string {
+	if e.Prefix == "" {
+		return "no releases exist to compare against"
+	}
+	return fmt.Sprintf("no releases exist with the tag prefix '%s' to compare against", e.Prefix)
+}
+
+// TypeGroup contains all commits that share a conventional commit type
+type TypeGroup struct {
+	Type    string
+	Commits []git.LogEntry
+}
+
+// Comparison describes all changes made to a path between two refs
+type Comparison struct {
+	// From is the ref changes are compared from. It will be empty if the
+	// comparison covers the entire history
+	From string
+
+	// To is the ref changes are compared to
+	To string
+
+	// Log contains all commits between the two refs, excluding any that were
+	// reverted. Commits are ordered from newest to oldest
+	Log []git.LogEntry
+
+	// Groups contains the log grouped by conventional commit type. Features and
+	// fixes are listed first, with any unconventional commits listed last
+	Groups []TypeGroup
+
+	// Breaking contains any commits that introduce a breaking change
+	Breaking []git.LogEntry
+
+	// Files lists every file changed within the path, relative to the root of
+	// the repository
+	Files []string
+
+	// Increment that the changes would trigger
+	Increment Increment
+}
+
+// Compare lists all changes made to a path between two refs. If no to ref is provided,
+// the latest release is used. If no from ref is provided, the release before the to
+// ref is used
+func Compare(repo Repository, from, to string, opts Options) (*Comparison, error) {
+	if err := checkAndHealRepository(repo, opts); err != nil {
+		return nil, err
+	}
+
+	ctx, err := resolveContext(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == "" || to == "" {
+		if from, to, err = resolveReleaseRange(repo, from, to, ctx.TagPrefix); err != nil {
+			return nil, err
+		}
+	}
+	opts.Logger.Info("comparing releases", "from", from, "to", to, "log_path", ctx.LogPath)
+
+	log, err := repo.Log(to, from, ctx.LogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reverted commits cancel each other out and are never part of the comparison
+	reverts := DetectReverts(log)
+	log, _ = activeCommits(log, reverts, nil)
+
+	ignoreOpts := opts
+	ignoreOpts.Ref = to
+	ignored, err := detectIgnored(repo, log, from, ctx.LogPath, ignoreOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ignored commits are still listed, but take no part in detecting the increment
+	active, indexes := activeCommits(log, nil, ignored)
+	_, inc, _, err := detectIncrement(log, active, indexes, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := repo.ChangedFiles(from, to, ctx.LogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &Comparison{
+		From:      from,
+		To:        to,
+		Log:       log,
+		Files:     files,
+		Increment: inc,
+	}
+
+	groups := map[string]*TypeGroup{}
+	for _, entry := range log {
+		typ, breaking := commitType(entry.Message)
+		if breaking {
+			cmp.Breaking = append(cmp.Breaking, entry)
+		}
+
+		if _, found := groups[typ]; !found {
+			groups[typ] = &TypeGroup{Type: typ}
+		}
+		groups[typ].Commits = append(groups[typ].Commits, entry)
+	}
+
+	for _, group := range groups {
+		cmp.Groups = append(cmp.Groups, *group)
+	}
+	slices.SortFunc(cmp.Groups, func(a, b TypeGroup) int {
+		if typeOrder(a.Type) != typeOrder(b.Type) {
+			return typeOrder(a.Type) - typeOrder(b.Type)
+		}
+		return strings.Compare(a.Type, b.Type)
+	})
+
+	return cmp, nil
+}
+
+// resolveReleaseRange defaults the to ref to the latest release, and the from ref to
+// the release before it. A to ref that is not a release is compared from the latest
+// release reachable from it
+func resolveReleaseRange(repo Repository, from, to, prefix string) (string, string, error) {
+	all, err := repo.Tags(to)
+	if err != nil {
+		return "", "", err
+	}
+
+	tags := sortedTags(all, prefix)
+	if to == "" {
+		if len(tags) == 0 {
+			return "", "", NoReleasesError{Prefix: prefix}
+		}
+		to = tags[len(tags)-1]
+	}
+
+	if from != "" {
+		return from, to, nil
+	}
+
+	idx := slices.Index(tags, to)
+	switch {
+	case idx > 0:
+		from = tags[idx-1]
+	case idx == -1 && len(tags) > 0:
+		from = tags[len(tags)-1]
+	}
+
+	return from, to, nil
+}
+
+// commitType extracts the lowercase type of a conventional commit, and whether
+// it introduces a breaking change
+func commitType(msg string) (string, bool) {
+	idx := strings.Index(msg, colonSpace)
+	if idx <= 0 {
+		return OtherType, false
+	}
+
+	leadingType := msg[:idx]
+	bang := leadingType[len(leadingType)-1] == breakingBang
+	if bang {
+		leadingType = leadingType[:len(leadingType)-1]
+	}
+
+	typ, _, ok := splitType(leadingType)
+	if !ok {
+		return OtherType, false
+	}
+
+	footerBreaking, _, _ := multilineBreaking(msg)
+	return strings.ToLower(typ), bang || footerBreaking
+}
+
+func typeOrder(typ string) int {
+	if idx := slices.Index(leadingTypes, typ); idx != -1 {
+		return idx
+	}
+
+	if typ == OtherType {
+		return len(leadingTypes) + 1
+	}
+	return len(leadingTypes)
+}
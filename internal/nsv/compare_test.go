@@ -0,0 +1,149 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func groupTypes(groups []nsv.TypeGroup) []string {
+	types := make([]string, 0, len(groups))
+	for _, group := range groups {
+		types = append(types, group.Type)
+	}
+	return types
+}
+
+func TestCompare(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+	repo.Commit("Update README.md", "README.md")
+	repo.Commit("docs: document search filters", "docs/search.md")
+	repo.Commit("feat(api)!: drop support for the v1 search api", "api/v1.go")
+	repo.Commit("fix: search results include deleted documents", "search.go")
+	require.NoError(t, repo.Tag("0.2.0", "", ""))
+	repo.Commit("fix: pagination off by one", "search.go")
+
+	cmp, err := nsv.Compare(repo, "", "", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.1.0", cmp.From)
+	assert.Equal(t, "0.2.0", cmp.To)
+	assert.Len(t, cmp.Log, 5)
+	assert.Equal(t, nsv.MajorIncrement, cmp.Increment)
+	assert.Equal(t, []string{"feat", "fix", "docs", nsv.OtherType}, groupTypes(cmp.Groups))
+	assert.Len(t, cmp.Groups[1].Commits, 2)
+
+	require.Len(t, cmp.Breaking, 1)
+	assert.Equal(t, "feat(api)!: drop support for the v1 search api", cmp.Breaking[0].Message)
+	assert.Equal(t, []string{"README.md", "api/v1.go", "docs/search.md", "search.go"}, cmp.Files)
+}
+
+func TestCompareWithRefs(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+	require.NoError(t, repo.Tag("0.1.1", "", ""))
+	repo.Commit("feat: support pagination of search results", "search.go")
+
+	cmp, err := nsv.Compare(repo, "0.1.0", "HEAD", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Len(t, cmp.Log, 2)
+	assert.Equal(t, []string{"feat", "fix"}, groupTypes(cmp.Groups))
+}
+
+func TestCompareFromLatestReleaseToUnreleased(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix: search results not sorted", "search.go")
+
+	cmp, err := nsv.Compare(repo, "", "HEAD", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.1.0", cmp.From)
+	assert.Len(t, cmp.Log, 1)
+	assert.Equal(t, nsv.PatchIncrement, cmp.Increment)
+}
+
+func TestCompareFirstRelease(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	repo.Commit("ci: cache go modules", ".github/workflows/ci.yml")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+
+	cmp, err := nsv.Compare(repo, "", "", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Empty(t, cmp.From)
+	assert.Len(t, cmp.Log, 2)
+}
+
+func TestCompareWithPath(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat(ui): initial search ui", "src/ui/index.ts")
+	require.NoError(t, repo.Tag("ui/0.1.0", "", ""))
+	repo.Commit("feat(search): support searching by tags", "src/search/search.go")
+	repo.Commit("fix(ui): dark mode toggle not persisted", "src/ui/theme.ts")
+	require.NoError(t, repo.Tag("ui/0.1.1", "", ""))
+
+	cmp, err := nsv.Compare(repo, "", "", nsv.Options{Logger: noopLogger, Path: "src/ui"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ui/0.1.0", cmp.From)
+	assert.Equal(t, "ui/0.1.1", cmp.To)
+	assert.Len(t, cmp.Log, 1)
+	assert.Equal(t, []string{"src/ui/theme.ts"}, cmp.Files)
+}
+
+func TestCompareExcludesReverts(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	hash, _ := repo.Commit("feat!: switch search to use graphql", "search.go")
+	repo.Commit(`Revert "feat!: switch search to use graphql"
+
+This reverts commit `+hash+`.`, "search.go")
+	repo.Commit("fix: search results not sorted", "search.go")
+	require.NoError(t, repo.Tag("0.1.1", "", ""))
+
+	cmp, err := nsv.Compare(repo, "", "", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Len(t, cmp.Log, 1)
+	assert.Empty(t, cmp.Breaking)
+}
+
+func TestCompareNoReleases(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+
+	_, err := nsv.Compare(repo, "", "", nsv.Options{Logger: noopLogger})
+	require.EqualError(t, err, "no releases exist to compare against")
+}
+
+func TestCompareGitRepository(t *testing.T) {
+	log := `(tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	gittest.TempFile(t, "search.go", "package search")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "fix: search results not sorted")
+	gittest.MustExec(t, "git tag 0.1.1")
+
+	gitc, _ := git.NewClient()
+	cmp, err := nsv.Compare(nsv.NewGitRepository(gitc), "", "", nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.1.0", cmp.From)
+	assert.Equal(t, "0.1.1", cmp.To)
+	assert.Equal(t, []string{"search.go"}, cmp.Files)
+}
@@ -0,0 +1,86 @@
+package nsv
+
+import (
+	"fmt"
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+const (
+	AngularConvention = "angular"
+	GitmojiConvention = "gitmoji"
+	RegexConvention   = "regex"
+)
+
+var Conventions = []string{AngularConvention, GitmojiConvention, RegexConvention}
+
+type UnsupportedConventionError struct {
+	Convention string
+}
+
+func (e UnsupportedConventionError) Error() string {
+	return fmt.Sprintf("commit convention '%s' is not supported, must be one of either: %s",
+		e.Convention, strings.Join(Conventions, ", "))
+}
+
+// Convention detects the semantic version increment that should be applied
+// based on the commit messages within a log. The commit that triggered the
+// increment is identified through a match
+type Convention interface {
+	DetectIncrement(log []git.LogEntry) (Increment, Match)
+}
+
+// NewConvention selects a commit convention based on the provided options,
+// defaulting to the angular convention
+func NewConvention(opts Options) (Convention, error) {
+	switch strings.ToLower(opts.Convention) {
+	case "", AngularConvention:
+		rules, err := AngularRules(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return ConventionalStrategy{Rules: rules, ParseBody: opts.ParseBody}, nil
+	case GitmojiConvention:
+		return Gitmoji(), nil
+	case RegexConvention:
+		return Regex(opts.MajorPattern, opts.MinorPattern, opts.PatchPattern)
+	default:
+		return nil, UnsupportedConventionError{Convention: opts.Convention}
+	}
+}
+
+// detectHighest scans the log for the highest increment using the provided detector.
+// The first commit to trigger the highest increment wins, with a major increment
+// immediately halting any further scanning
+func detectHighest(log []git.LogEntry, detect func(msg string) (Increment, int, int)) (Increment, Match) {
+	mode := NoIncrement
+	match := NoMatch
+
+	for i, entry := range log {
+		inc, start, end := detect(entry.Message)
+		if inc > mode {
+			mode = inc
+			match = Match{Index: i, Start: start, End: end}
+		}
+
+		if mode == MajorIncrement {
+			break
+		}
+	}
+
+	return mode, match
+}
+
+// AngularRules builds the effective table of rules for the angular convention.
+// Any explicitly defined rules are applied on top of the list of prefixes
+func AngularRules(opts Options) (Rules, error) {
+	rules, err := ParseRules(opts.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := AngularMerge(opts.MajorPrefixes, opts.MinorPrefixes, opts.PatchPrefixes)
+	return append(strategy.Rules, rules...), nil
+}
@@ -0,0 +1,183 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConvention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		convention string
+		expected   nsv.Convention
+	}{
+		{
+			name:       "DefaultsToAngular",
+			convention: "",
+			expected:   nsv.AngularMerge(nil, nil, nil),
+		},
+		{
+			name:       "Angular",
+			convention: nsv.AngularConvention,
+			expected:   nsv.AngularMerge(nil, nil, nil),
+		},
+		{
+			name:       "Gitmoji",
+			convention: "GITMOJI",
+			expected:   nsv.Gitmoji(),
+		},
+		{
+			name:       "Regex",
+			convention: nsv.RegexConvention,
+			expected:   nsv.RegexStrategy{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := nsv.NewConvention(nsv.Options{Convention: tt.convention})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conv)
+		})
+	}
+}
+
+func TestNewConventionUnsupported(t *testing.T) {
+	_, err := nsv.NewConvention(nsv.Options{Convention: "emoji"})
+
+	require.EqualError(t, err, "commit convention 'emoji' is not supported, must be one of either: angular, gitmoji, regex")
+}
+
+func TestNewConventionInvalidPattern(t *testing.T) {
+	_, err := nsv.NewConvention(nsv.Options{
+		Convention:   nsv.RegexConvention,
+		MinorPattern: "^feature(",
+	})
+
+	require.Error(t, err)
+}
+
+func TestGitmojiDetectIncrement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		commit string
+		inc    nsv.Increment
+		match  nsv.Match
+	}{
+		{
+			name:   "NoIncrement",
+			commit: ":memo: document the new search flags",
+			inc:    nsv.NoIncrement,
+			match:  nsv.NoMatch,
+		},
+		{
+			name:   "CodePatchIncrement",
+			commit: ":bug: incorrect cache retrieval based on key",
+			inc:    nsv.PatchIncrement,
+			match:  nsv.Match{Start: 0, End: 5},
+		},
+		{
+			name:   "CodeMinorIncrement",
+			commit: ":sparkles: add fuzzy finding to predictive search",
+			inc:    nsv.MinorIncrement,
+			match:  nsv.Match{Start: 0, End: 10},
+		},
+		{
+			name:   "CodeMajorIncrement",
+			commit: ":boom: rewrite of existing API",
+			inc:    nsv.MajorIncrement,
+			match:  nsv.Match{Start: 0, End: 6},
+		},
+		{
+			name:   "OnlyLeadingCode",
+			commit: ":memo::sparkles: document the search feature",
+			inc:    nsv.NoIncrement,
+			match:  nsv.NoMatch,
+		},
+		{
+			name:   "UnicodeMinorIncrement",
+			commit: "✨ add fuzzy finding to predictive search",
+			inc:    nsv.MinorIncrement,
+			match:  nsv.Match{Start: 0, End: 3},
+		},
+		{
+			name:   "UnicodeWithVariationSelector",
+			commit: "⬆️ bump action from 1.0.1 to 1.1.0",
+			inc:    nsv.PatchIncrement,
+			match:  nsv.Match{Start: 0, End: 6},
+		},
+		{
+			name:   "LeadingWhitespace",
+			commit: "  :ambulance: critical fix to login flow",
+			inc:    nsv.PatchIncrement,
+			match:  nsv.Match{Start: 2, End: 13},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inc, match := nsv.Gitmoji().DetectIncrement([]git.LogEntry{
+				{Message: tt.commit},
+			})
+
+			assert.Equal(t, tt.inc, inc)
+			assert.Equal(t, tt.match, match)
+		})
+	}
+}
+
+func TestRegexDetectIncrement(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := nsv.Regex(`(?m)^BREAKING:`, `^\[feature\]`, `^\[(bugfix|security)\]`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		commit string
+		inc    nsv.Increment
+		match  nsv.Match
+	}{
+		{
+			name:   "NoIncrement",
+			commit: "[docs] document the new search flags",
+			inc:    nsv.NoIncrement,
+			match:  nsv.NoMatch,
+		},
+		{
+			name:   "PatchIncrement",
+			commit: "[security] sanitize user input",
+			inc:    nsv.PatchIncrement,
+			match:  nsv.Match{Start: 0, End: 10},
+		},
+		{
+			name:   "MinorIncrement",
+			commit: "[feature] add fuzzy finding to predictive search",
+			inc:    nsv.MinorIncrement,
+			match:  nsv.Match{Start: 0, End: 9},
+		},
+		{
+			name:   "MajorIncrementWithinBody",
+			commit: "[feature] new api\n\nBREAKING: removed the v1 endpoints",
+			inc:    nsv.MajorIncrement,
+			match:  nsv.Match{Start: 19, End: 28},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inc, match := strategy.DetectIncrement([]git.LogEntry{
+				{Message: tt.commit},
+			})
+
+			assert.Equal(t, tt.inc, inc)
+			assert.Equal(t, tt.match, match)
+		})
+	}
+}
@@ -21,111 +21,122 @@ var (
 )
 
 type ConventionalStrategy struct {
-	MajorPrefixes []string
-	MinorPrefixes []string
-	PatchPrefixes []string
+	// Rules maps each conventional commit type, and optional scope, to
+	// an increment. Any type without a rule will not trigger an increment
+	Rules Rules
+
+	// ParseBody enables the parsing of bullet-listed conventional commits within
+	// the body of a commit, typical of squash and merge commits. The highest
+	// increment between the first line and body of the commit will be used
+	ParseBody bool
 }
 
 func Angular() ConventionalStrategy {
-	return ConventionalStrategy{
-		MajorPrefixes: []string{},
-		MinorPrefixes: minorDefault,
-		PatchPrefixes: patchDefault,
-	}
+	return AngularMerge(nil, nil, nil)
 }
 
 func AngularMerge(major, minor, patch []string) ConventionalStrategy {
-	minorPrefs := minorDefault
-	if len(minor) > 0 {
-		minorPrefs = toUpper(minor)
-	}
-	patchPrefs := patchDefault
-	if len(patch) > 0 {
-		patchPrefs = toUpper(patch)
+	if len(minor) == 0 {
+		minor = minorDefault
 	}
-
-	return ConventionalStrategy{
-		MajorPrefixes: toUpper(major),
-		MinorPrefixes: minorPrefs,
-		PatchPrefixes: patchPrefs,
+	if len(patch) == 0 {
+		patch = patchDefault
 	}
-}
 
-func toUpper(s []string) []string {
-	upper := make([]string, 0, len(s))
-	for _, se := range s {
-		upper = append(upper, strings.ToUpper(se))
-	}
+	// Ordered by increment, ensuring the highest increment wins should a
+	// type appear within more than one list of prefixes
+	var rules Rules
+	rules = append(rules, prefixRules(patch, PatchIncrement)...)
+	rules = append(rules, prefixRules(minor, MinorIncrement)...)
+	rules = append(rules, prefixRules(major, MajorIncrement)...)
 
-	return upper
+	return ConventionalStrategy{Rules: rules}
 }
 
 func (s ConventionalStrategy) DetectIncrement(log []git.LogEntry) (Increment, Match) {
-	mode := NoIncrement
-	match := NoMatch
+	return detectHighest(log, s.detectMessage)
+}
 
-	for i, entry := range log {
-		// Check for the existence of a conventional commit type
-		idx := strings.Index(entry.Message, colonSpace)
-		if idx == -1 {
-			continue
-		}
+func (s ConventionalStrategy) detectMessage(msg string) (Increment, int, int) {
+	// Check for the existence of a conventional commit type
+	idx := strings.Index(msg, colonSpace)
+	if idx <= 0 {
+		return NoIncrement, noMatchIdx, noMatchIdx
+	}
 
-		leadingType := strings.ToUpper(entry.Message[:idx])
+	if msg[idx-1] == breakingBang {
+		return MajorIncrement, 0, idx
+	}
 
-		if leadingType[idx-1] == breakingBang {
-			return MajorIncrement, Match{Index: i, End: idx}
-		}
+	if found, start, end := multilineBreaking(msg); found {
+		return MajorIncrement, start, end
+	}
 
-		if found, start, end := multilineBreaking(entry.Message); found {
-			return MajorIncrement, Match{Index: i, Start: start, End: end}
+	inc, start, end := s.typeIncrement(msg[:idx]), 0, idx
+	if s.ParseBody {
+		if bodyInc, bodyStart, bodyEnd := s.detectBody(msg); bodyInc > inc {
+			inc, start, end = bodyInc, bodyStart, bodyEnd
 		}
+	}
 
-		if contains(s.MajorPrefixes, leadingType) {
-			return MajorIncrement, Match{Index: i, End: idx}
-		}
+	return inc, start, end
+}
 
-		if mode == MinorIncrement && match.Index > noMatchIdx {
+// detectBody scans the body of a commit for a bullet-listed set of conventional
+// commits, as generated when squashing or merging a pull request:
+//
+//	feat: support searching by tags (#12)
+//
+//	* feat: add tags to search index
+//	* fix(ui): search bar loses focus
+func (s ConventionalStrategy) detectBody(msg string) (Increment, int, int) {
+	inc, start, end := NoIncrement, noMatchIdx, noMatchIdx
+
+	pos := 0
+	for n, line := range strings.Split(msg, "\n") {
+		lineStart := pos
+		pos += len(line) + 1
+		if n == 0 {
 			continue
 		}
 
-		if contains(s.MinorPrefixes, leadingType) {
-			mode = MinorIncrement
-			match = Match{Index: i, End: idx}
+		item := strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(item, "* ") && !strings.HasPrefix(item, "- ") {
+			continue
 		}
+		itemStart := lineStart + (len(line) - len(item)) + 2
+		item = item[2:]
 
-		if mode == PatchIncrement && match.Index > noMatchIdx {
+		idx := strings.Index(item, colonSpace)
+		if idx <= 0 {
 			continue
 		}
 
-		if contains(s.PatchPrefixes, leadingType) {
-			mode = PatchIncrement
-			match = Match{Index: i, End: idx}
+		itemInc := s.typeIncrement(item[:idx])
+		if item[idx-1] == breakingBang {
+			itemInc = MajorIncrement
 		}
-	}
 
-	return mode, match
-}
+		if itemInc > inc {
+			inc, start, end = itemInc, itemStart, itemStart+idx
+		}
 
-func contains(prefixes []string, str string) bool {
-	if len(prefixes) == 0 {
-		return false
+		if inc == MajorIncrement {
+			break
+		}
 	}
 
-	for _, prefix := range prefixes {
-		if str == prefix {
-			return true
-		}
+	return inc, start, end
+}
 
-		if strings.HasPrefix(str, prefix) {
-			if len(str) > len(prefix) &&
-				(str[len(prefix)] == '(' && str[len(str)-1] == ')') {
-				return true
-			}
-		}
+func (s ConventionalStrategy) typeIncrement(leadingType string) Increment {
+	typ, scope, ok := splitType(leadingType)
+	if !ok {
+		typ, scope = splitCustomType(leadingType)
 	}
 
-	return false
+	inc, _ := s.Rules.Lookup(typ, scope)
+	return inc
 }
 
 func multilineBreaking(msg string) (bool, int, int) {
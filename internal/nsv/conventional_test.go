@@ -252,6 +252,18 @@ func TestDetectIncrementWithCustomPrefixes(t *testing.T) {
 			commit:        "chore(deps): bump github.com/charmbracelet/lipgloss from 0.10.0 to 0.11.0",
 			expected:      nsv.PatchIncrement,
 		},
+		{
+			name:          "CustomType",
+			minorPrefixes: []string{"chore.deps"},
+			commit:        "chore.deps(ui): bump github.com/sveltejs/svelte from 4.2.17 to 5.0.0",
+			expected:      nsv.MinorIncrement,
+		},
+		{
+			name:          "CustomTypeWithScope",
+			patchPrefixes: []string{"release/ui(docs)"},
+			commit:        "release/ui(docs): document the search api",
+			expected:      nsv.PatchIncrement,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -272,6 +284,83 @@ func TestDetectIncrementWithCustomPrefixes(t *testing.T) {
 	}
 }
 
+func TestDetectIncrementParseBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		commit string
+		inc    nsv.Increment
+		match  nsv.Match
+	}{
+		{
+			name: "SquashBody",
+			commit: `chore: tidy up search module (#12)
+
+* feat(search): support searching by tags
+* fix: search bar loses focus`,
+			inc:   nsv.MinorIncrement,
+			match: nsv.Match{Start: 38, End: 50},
+		},
+		{
+			name: "MergeBody",
+			commit: `Merge pull request #12 from purpleclay/search
+
+  - fix: search bar loses focus
+  - refactor!: rename search options`,
+			inc:   nsv.MajorIncrement,
+			match: nsv.Match{Start: 83, End: 92},
+		},
+		{
+			name: "TitleTakesPrecedence",
+			commit: `feat: support searching by tags (#12)
+
+* fix: search bar loses focus`,
+			inc:   nsv.MinorIncrement,
+			match: nsv.Match{Start: 0, End: 4},
+		},
+		{
+			name: "IgnoresNonBulletedLines",
+			commit: `chore: tidy up search module (#12)
+
+feat: this is not a bulleted commit`,
+			inc:   nsv.NoIncrement,
+			match: nsv.Match{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy := nsv.Angular()
+			strategy.ParseBody = true
+
+			inc, match := strategy.DetectIncrement([]git.LogEntry{
+				{
+					Message: tt.commit,
+				},
+			})
+			require.Equal(t, tt.inc, inc, "failed to match increment")
+			require.Equal(t, tt.match.Start, match.Start, "failed to match starting index")
+			require.Equal(t, tt.match.End, match.End, "failed to match end index")
+		})
+	}
+}
+
+func TestDetectIncrementIgnoresBodyByDefault(t *testing.T) {
+	t.Parallel()
+
+	inc, _ := nsv.Angular().DetectIncrement([]git.LogEntry{
+		{
+			Message: `chore: tidy up search module (#12)
+
+* feat(search): support searching by tags`,
+		},
+	})
+	assert.Equal(t, nsv.NoIncrement, inc)
+}
+
 // globals are used to prevent any compiler optimizations
 var (
 	gInc      nsv.Increment
@@ -310,3 +399,63 @@ BREAKING-CHANGE: backwards compatibility with v1 is no longer supported`},
 	gInc = inc
 	gMatch = m
 }
+
+func TestDetectIncrementWithRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := nsv.ParseRules([]string{"feat(internal)=patch", "perf(*)=patch", "docs(readme)=none"})
+	require.NoError(t, err)
+
+	strategy := nsv.Angular()
+	strategy.Rules = append(strategy.Rules, rules...)
+
+	tests := []struct {
+		name     string
+		commit   string
+		expected nsv.Increment
+	}{
+		{
+			name:     "ScopedOverride",
+			commit:   "feat(internal): cache compiled templates",
+			expected: nsv.PatchIncrement,
+		},
+		{
+			name:     "UnscopedFallback",
+			commit:   "feat(ui): add dark mode toggle",
+			expected: nsv.MinorIncrement,
+		},
+		{
+			name:     "AnyScopeRequiresScope",
+			commit:   "perf: reduce allocations when parsing tags",
+			expected: nsv.NoIncrement,
+		},
+		{
+			name:     "AnyScope",
+			commit:   "perf(search): reduce allocations when querying",
+			expected: nsv.PatchIncrement,
+		},
+		{
+			name:     "ExplicitNone",
+			commit:   "docs(readme): fix typo in installation guide",
+			expected: nsv.NoIncrement,
+		},
+		{
+			name:     "FixupIsNotFix",
+			commit:   "FIXUP: incorrect cache retrieval based on key",
+			expected: nsv.NoIncrement,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			inc, _ := strategy.DetectIncrement([]git.LogEntry{
+				{
+					Message: tt.commit,
+				},
+			})
+			require.Equal(t, tt.expected, inc, "failed to match increment")
+		})
+	}
+}
@@ -1,42 +1,147 @@
 package nsv
 
 import (
-	"errors"
-	"os"
-	"path/filepath"
-
-	"github.com/saracen/walker"
+	"bufio"
+	"io/fs"
+	"path"
+	"strings"
 )
 
-var (
-	vprefixed = map[string]struct{}{
-		"go.mod": {},
-	}
+// Language describes the versioning conventions of an ecosystem, detected
+// through the presence of its manifest files
+type Language struct {
+	// Name of the ecosystem
+	Name string
 
-	// a custom error that is never propagated, but used to circuit break the file walker
-	errLanguageDetected = errors.New("language detected")
-)
+	// Manifests contains a list of glob patterns, relative to the directory
+	// being versioned, that identify the ecosystem, e.g. charts/*/Chart.yaml
+	Manifests []string
+
+	// VPrefix determines if tags are conventionally prefixed with a v. Unless
+	// required, the prefix is only applied when opted into
+	VPrefix bool
+
+	// VPrefixRequired determines if tags must always be prefixed with a v
+	VPrefixRequired bool
+
+	// InitialVersion is the version released when no previous tag exists. If
+	// empty, the first semantic version is bumped from 0.0.0
+	InitialVersion string
+
+	// TagFormat resolves the default go template for formatting tags from the
+	// path of the detected manifest within the working tree. If not set, tags
+	// are not formatted
+	TagFormat func(fsys fs.FS, manifest string) string
+}
 
-func detectLanguageIsPrefixed(dir string) bool {
-	root := dir
-	if root == "" {
-		root = "."
+// Languages is a registry of all supported ecosystems. If multiple ecosystems
+// are detected, the first one within the registry takes precedence. Node is
+// deliberately last, as a package.json is often used for tooling alone
+var Languages = []Language{
+	{Name: "go", Manifests: []string{"go.mod"}, VPrefix: true, VPrefixRequired: true},
+	{Name: "rust", Manifests: []string{"Cargo.toml"}, VPrefix: true, InitialVersion: "0.1.0"},
+	{Name: "maven", Manifests: []string{"pom.xml"}},
+	{Name: "python", Manifests: []string{"pyproject.toml", "setup.py", "setup.cfg"}, InitialVersion: "0.1.0"},
+	{
+		Name:           "helm",
+		Manifests:      []string{"Chart.yaml", "chart/Chart.yaml", "charts/*/Chart.yaml"},
+		InitialVersion: "0.1.0",
+		TagFormat:      helmTagFormat,
+	},
+	{Name: "terraform", Manifests: []string{"*.tf"}, VPrefix: true},
+	{Name: "node", Manifests: []string{"package.json"}, VPrefix: true},
+}
+
+// directories that never contain the manifest of the ecosystem being versioned
+var skipDirs = map[string]struct{}{
+	"node_modules": {},
+	"target":       {},
+	"vendor":       {},
+}
+
+// detectLanguage walks a directory of the working tree, and any subdirectories needed
+// by a manifest pattern, returning the ecosystem with the highest precedence along with
+// the path to its manifest. If many manifests match, the first in lexical order is returned
+func detectLanguage(fsys fs.FS, dir string) (Language, string, bool) {
+	root := path.Clean(dir)
+	if !fs.ValidPath(root) {
+		return Language{}, "", false
 	}
 
-	err := walker.Walk(root,
-		func(pathname string, fi os.FileInfo) error {
-			if pathname == root {
-				return nil
+	depth := 0
+	for _, lang := range Languages {
+		for _, manifest := range lang.Manifests {
+			depth = max(depth, strings.Count(manifest, "/"))
+		}
+	}
+
+	detected := len(Languages)
+	var manifest string
+
+	_ = fs.WalkDir(fsys, root, func(pathname string, d fs.DirEntry, err error) error {
+		if err != nil || pathname == root {
+			return nil
+		}
+
+		rel := strings.TrimPrefix(pathname, root+"/")
+		if root == "." {
+			rel = pathname
+		}
+
+		if d.IsDir() {
+			if _, skip := skipDirs[d.Name()]; skip || strings.HasPrefix(d.Name(), ".") ||
+				strings.Count(rel, "/") >= depth {
+				return fs.SkipDir
 			}
+			return nil
+		}
 
-			if fi.IsDir() {
-				return filepath.SkipDir
+		for i, lang := range Languages {
+			if !matchesManifest(rel, lang.Manifests) {
+				continue
 			}
 
-			if _, matched := vprefixed[fi.Name()]; matched {
-				return errLanguageDetected
+			if i < detected || (i == detected && rel < manifest) {
+				detected = i
+				manifest = rel
 			}
-			return nil
-		})
-	return errors.Is(err, errLanguageDetected)
+			break
+		}
+		return nil
+	})
+
+	if detected == len(Languages) {
+		return Language{}, "", false
+	}
+	return Languages[detected], path.Join(root, manifest), true
+}
+
+func matchesManifest(rel string, manifests []string) bool {
+	for _, manifest := range manifests {
+		if matched, _ := path.Match(manifest, rel); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// helmTagFormat tags a chart as <chart>-<version>, the convention used by the helm
+// chart releaser. The name of the chart is read from its Chart.yaml
+func helmTagFormat(fsys fs.FS, manifest string) string {
+	f, err := fsys.Open(manifest)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name, found := strings.CutPrefix(scanner.Text(), "name:"); found {
+			if name = strings.Trim(strings.TrimSpace(name), `"'`); name != "" {
+				return name + "-{{.SemVer}}"
+			}
+		}
+	}
+
+	return ""
 }
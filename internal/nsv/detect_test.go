@@ -0,0 +1,178 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersionDetectsLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		vPrefix  bool
+		expected string
+	}{
+		{
+			name:     "Go",
+			files:    []string{"go.mod"},
+			expected: "v0.1.0",
+		},
+		{
+			name:     "Rust",
+			files:    []string{"Cargo.toml"},
+			expected: "0.1.0",
+		},
+		{
+			name:     "RustWithVPrefix",
+			files:    []string{"Cargo.toml"},
+			vPrefix:  true,
+			expected: "v0.1.0",
+		},
+		{
+			name:     "Maven",
+			files:    []string{"pom.xml"},
+			vPrefix:  true,
+			expected: "0.1.0",
+		},
+		{
+			name:     "Python",
+			files:    []string{"pyproject.toml"},
+			expected: "0.1.0",
+		},
+		{
+			name:     "HelmNestedChart",
+			files:    []string{"charts/search/Chart.yaml"},
+			expected: "0.1.0",
+		},
+		{
+			name:     "Terraform",
+			files:    []string{"main.tf", "variables.tf"},
+			expected: "0.1.0",
+		},
+		{
+			name:     "TerraformWithVPrefix",
+			files:    []string{"main.tf", "variables.tf"},
+			vPrefix:  true,
+			expected: "v0.1.0",
+		},
+		{
+			name:     "Node",
+			files:    []string{"package.json"},
+			expected: "0.1.0",
+		},
+		{
+			name:     "NodeToolingWithinRust",
+			files:    []string{"package.json", "Cargo.toml"},
+			vPrefix:  true,
+			expected: "v0.1.0",
+		},
+		{
+			name:     "Unknown",
+			files:    []string{"README.md"},
+			expected: "0.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.InitRepository(t,
+				gittest.WithLog("(main) feat: support searching by tags"),
+				gittest.WithCommittedFiles(tt.files...))
+			gitc, _ := git.NewClient()
+
+			next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, VPrefix: tt.vPrefix})
+			require.NoError(t, err)
+			require.NotNil(t, next)
+			assert.Equal(t, tt.expected, next.Tag)
+		})
+	}
+}
+
+func TestNextVersionInitialVersionOfLanguage(t *testing.T) {
+	gittest.InitRepository(t,
+		gittest.WithLog("(main) fix: search results not sorted"),
+		gittest.WithCommittedFiles("Cargo.toml"))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.1.0", next.Tag)
+	assert.Equal(t, "0.0.0", next.PrevTag)
+}
+
+func TestNextVersionHelmTagFormat(t *testing.T) {
+	gittest.InitRepository(t,
+		gittest.WithLog("(main) feat: support searching by tags"),
+		gittest.WithCommittedFiles("Chart.yaml"),
+		gittest.WithFileContent("Chart.yaml", "apiVersion: v2\nname: search\nversion: 0.1.0\n"))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "search-0.1.0", next.Tag)
+	assert.Equal(t, "search-0.0.0", next.PrevTag)
+
+	gittest.MustExec(t, "git tag search-0.1.0")
+	gittest.TempFile(t, "values.yaml", "replicas: 2")
+	gittest.StageFile(t, "values.yaml")
+	gittest.Commit(t, "fix: search results not sorted")
+
+	next, err = nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "search-0.1.1", next.Tag)
+	assert.Equal(t, "search-0.1.0", next.PrevTag)
+}
+
+func TestNextVersionIgnoresManifestsBeyondDepth(t *testing.T) {
+	gittest.InitRepository(t,
+		gittest.WithLog("(main) feat: support searching by tags"),
+		gittest.WithCommittedFiles("node_modules/search/Cargo.toml", "examples/basic/go.mod"))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.1.0", next.Tag)
+}
+
+func TestNextVersionHelmExistingTags(t *testing.T) {
+	log := `(main) feat: support searching by tags
+(tag: 0.1.0) feat: deploy search using a helm chart`
+	gittest.InitRepository(t,
+		gittest.WithLog(log),
+		gittest.WithCommittedFiles("Chart.yaml"),
+		gittest.WithFileContent("Chart.yaml", "apiVersion: v2\nname: search\nversion: 0.1.0\n"))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.2.0", next.Tag)
+	assert.Equal(t, "0.1.0", next.PrevTag)
+}
+
+func TestNextVersionHelmExistingTagsWithPrefix(t *testing.T) {
+	gittest.InitRepository(t,
+		gittest.WithLog("(main) feat(ui): deploy ui using a helm chart"),
+		gittest.WithCommittedFiles("ui/Chart.yaml"),
+		gittest.WithFileContent("ui/Chart.yaml", "apiVersion: v2\nname: ui\nversion: 1.2.3\n"))
+	gittest.MustExec(t, "git tag ui/1.2.3")
+	gittest.TempFile(t, "ui/values.yaml", "theme: dark")
+	gittest.StageFile(t, "ui/values.yaml")
+	gittest.Commit(t, "feat(ui): support dark mode")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Path: "ui", Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "ui/1.3.0", next.Tag)
+	assert.Equal(t, "ui/1.2.3", next.PrevTag)
+}
@@ -0,0 +1,292 @@
+package nsv
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+const (
+	defaultRemote = "origin"
+
+	// the well-known hash of an empty tree, used to diff against the start of history
+	emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+)
+
+// GitRepository is a [Repository] backed by the git binary
+type GitRepository struct {
+	gitc   *git.Client
+	config []string
+}
+
+// NewGitRepository creates a [Repository] that shells out to git using the
+// provided client
+func NewGitRepository(gitc *git.Client) *GitRepository {
+	return &GitRepository{gitc: gitc}
+}
+
+// WithConfig returns a copy of the repository that sets git config when committing
+// and tagging. Config is provided as key value pairs, e.g. user.name, batman
+func (r *GitRepository) WithConfig(cfg ...string) *GitRepository {
+	return &GitRepository{gitc: r.gitc, config: cfg}
+}
+
+func (r *GitRepository) Info() (git.Repository, error) {
+	return r.gitc.Repository()
+}
+
+func (r *GitRepository) Unshallow() error {
+	_, err := r.gitc.Fetch(git.WithUnshallow(), git.WithTags())
+	return err
+}
+
+func (r *GitRepository) RelativePath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return r.gitc.ToRelativePath(cwd)
+}
+
+func (r *GitRepository) WorkTree() WorkTree {
+	return diskWorkTree{FS: os.DirFS(".")}
+}
+
+// diskWorkTree is a [WorkTree] rooted at the current working directory on disk
+type diskWorkTree struct {
+	fs.FS
+}
+
+func (diskWorkTree) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.WriteFile(filepath.FromSlash(name), data, perm)
+}
+
+func (r *GitRepository) Tags(ref string) ([]string, error) {
+	cmd := "git tag -l"
+	if ref != "" {
+		cmd += " --merged " + ref
+	}
+
+	out, err := r.gitc.Exec(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(out), nil
+}
+
+func (r *GitRepository) RemoteTags() ([]string, error) {
+	remote, err := r.pushRemote()
+	if err != nil {
+		return nil, err
+	}
+
+	remotes, err := r.gitc.Exec("git remote")
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(strings.Fields(remotes), remote) {
+		return nil, nil
+	}
+
+	out, err := r.gitc.Exec("git ls-remote --tags " + remote)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, ref, found := strings.Cut(line, "\t"); found {
+			tag := strings.TrimSuffix(strings.TrimPrefix(ref, "refs/tags/"), "^{}")
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+func (r *GitRepository) Log(ref, from, path string) ([]git.LogEntry, error) {
+	log, err := r.gitc.Log(git.WithPaths(path), git.WithRefRange(headIfEmpty(ref), from))
+	if err != nil {
+		return nil, err
+	}
+
+	return log.Commits, nil
+}
+
+func (r *GitRepository) MergeLog(ref, from, path string) ([]git.LogEntry, error) {
+	// Without a full history, git simplifies away any merge commit that is identical
+	// to one of its parents for the given path, which is always true of a --no-ff merge
+	out, err := r.gitc.Exec(fmt.Sprintf("git log --full-history --topo-order %s --pretty='format:%%H %%B%%-N%%x00' --no-color -- '%s'",
+		refRange(ref, from), path))
+	if err != nil {
+		return nil, err
+	}
+
+	var log []git.LogEntry
+	for _, commit := range strings.Split(out, "\x00") {
+		if hash, msg, found := strings.Cut(strings.TrimSpace(commit), " "); found {
+			log = append(log, git.LogEntry{
+				Hash:       hash,
+				AbbrevHash: hash[:7],
+				Message:    strings.ReplaceAll(msg, "\r\n", "\n"),
+			})
+		}
+	}
+
+	return log, nil
+}
+
+func (r *GitRepository) Authors(ref, from, path string) (map[string]string, error) {
+	out, err := r.gitc.Exec(fmt.Sprintf("git log --full-history %s --pretty='format:%%H %%ae' --no-color -- '%s'",
+		refRange(ref, from), path))
+	if err != nil {
+		return nil, err
+	}
+
+	authors := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if hash, email, found := strings.Cut(strings.TrimSpace(line), " "); found {
+			authors[hash] = email
+		}
+	}
+
+	return authors, nil
+}
+
+func (r *GitRepository) ChangedFiles(from, to, path string) ([]string, error) {
+	if from == "" {
+		from = emptyTree
+	}
+
+	out, err := r.gitc.Exec(fmt.Sprintf("git diff --name-only --no-color %s %s -- '%s'", from, headIfEmpty(to), path))
+	if err != nil {
+		return nil, err
+	}
+
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+func (r *GitRepository) Diff() ([]git.FileDiff, error) {
+	return r.gitc.Diff()
+}
+
+func (r *GitRepository) Config() (map[string]string, error) {
+	return r.gitc.Config()
+}
+
+func (r *GitRepository) Commit(msg string, paths ...string) (string, error) {
+	if len(paths) > 0 {
+		if _, err := r.gitc.Stage(git.WithPathSpecs(paths...)); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := r.gitc.Commit(msg, git.WithCommitConfig(r.config...)); err != nil {
+		return "", err
+	}
+
+	return r.gitc.Exec("git rev-parse " + git.HeadRef)
+}
+
+func (r *GitRepository) ShowTags(tags ...string) (map[string]git.TagDetails, error) {
+	details, err := r.gitc.ShowTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hash of the tagged commit is not included when showing a tag
+	for tag, detail := range details {
+		if detail.Commit.Ref, err = r.gitc.Exec(fmt.Sprintf("git rev-parse %s^{commit}", tag)); err != nil {
+			return nil, err
+		}
+		details[tag] = detail
+	}
+
+	return details, nil
+}
+
+func (r *GitRepository) Tag(tag, ref, annotation string) error {
+	opts := []git.CreateTagOption{git.WithTagConfig(r.config...), git.WithLocalOnly()}
+	if ref != "" {
+		opts = append(opts, git.WithCommitRef(ref))
+	}
+
+	if annotation == "" {
+		opts = append(opts, git.WithSkipSigning())
+	} else {
+		opts = append(opts, git.WithAnnotation(annotation))
+	}
+
+	_, err := r.gitc.Tag(tag, opts...)
+	return err
+}
+
+func (r *GitRepository) Push(refs ...string) error {
+	remote, err := r.pushRemote()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.gitc.Exec(fmt.Sprintf("git push %s %s", remote, strings.Join(refs, " ")))
+	return err
+}
+
+// pushRemote resolves the remote to push to, in the same order as git. The push remote
+// of the current branch, then the default push remote, then the remote the current branch
+// tracks. If none are configured, origin is used
+func (r *GitRepository) pushRemote() (string, error) {
+	cfg, err := r.gitc.Config()
+	if err != nil {
+		return "", err
+	}
+
+	branch, err := r.gitc.Exec("git branch --show-current")
+	if err != nil {
+		return "", err
+	}
+
+	// Section and variable names are always listed in lowercase by git config
+	keys := []string{"remote.pushdefault"}
+	if branch != "" {
+		keys = []string{"branch." + branch + ".pushremote", "remote.pushdefault", "branch." + branch + ".remote"}
+	}
+
+	for _, key := range keys {
+		// A remote of . refers to the local repository
+		if remote := cfg[key]; remote != "" && remote != "." {
+			return remote, nil
+		}
+	}
+
+	return defaultRemote, nil
+}
+
+func refRange(ref, from string) string {
+	if from == "" {
+		return headIfEmpty(ref)
+	}
+	return fmt.Sprintf("%s...%s", headIfEmpty(ref), from)
+}
+
+func headIfEmpty(ref string) string {
+	if ref == "" {
+		return git.HeadRef
+	}
+	return ref
+}
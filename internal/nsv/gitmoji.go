@@ -0,0 +1,96 @@
+package nsv
+
+import (
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+const variationSelector = "\ufe0f"
+
+// GitmojiStrategy detects increments from commits that follow the gitmoji
+// convention, where each commit is prefixed with either an emoji code or
+// its unicode equivalent, https://gitmoji.dev
+type GitmojiStrategy struct {
+	Emojis map[string]Increment
+
+	// Unicode lists each unicode emoji in the order it is checked, so the same
+	// emoji is always detected
+	Unicode []string
+}
+
+// Gitmoji returns a strategy that maps each gitmoji to an increment based
+// on its documented semver impact
+func Gitmoji() GitmojiStrategy {
+	emojis := map[string]Increment{}
+	var unicode []string
+	for _, gm := range []struct {
+		code    string
+		unicode string
+		inc     Increment
+	}{
+		{":boom:", "💥", MajorIncrement},
+		{":sparkles:", "✨", MinorIncrement},
+		{":adhesive_bandage:", "🩹", PatchIncrement},
+		{":alien:", "👽", PatchIncrement},
+		{":ambulance:", "🚑", PatchIncrement},
+		{":arrow_down:", "⬇", PatchIncrement},
+		{":arrow_up:", "⬆", PatchIncrement},
+		{":bug:", "🐛", PatchIncrement},
+		{":children_crossing:", "🚸", PatchIncrement},
+		{":globe_with_meridians:", "🌐", PatchIncrement},
+		{":lipstick:", "💄", PatchIncrement},
+		{":lock:", "🔒", PatchIncrement},
+		{":pencil2:", "✏", PatchIncrement},
+		{":pushpin:", "📌", PatchIncrement},
+		{":rewind:", "⏪", PatchIncrement},
+		{":speech_balloon:", "💬", PatchIncrement},
+		{":wheelchair:", "♿", PatchIncrement},
+		{":zap:", "⚡", PatchIncrement},
+	} {
+		emojis[gm.code] = gm.inc
+		emojis[gm.unicode] = gm.inc
+		unicode = append(unicode, gm.unicode)
+	}
+
+	return GitmojiStrategy{Emojis: emojis, Unicode: unicode}
+}
+
+func (s GitmojiStrategy) DetectIncrement(log []git.LogEntry) (Increment, Match) {
+	return detectHighest(log, s.detectMessage)
+}
+
+func (s GitmojiStrategy) detectMessage(msg string) (Increment, int, int) {
+	start := len(msg) - len(strings.TrimLeft(msg, " "))
+	subject := msg[start:]
+
+	if strings.HasPrefix(subject, ":") {
+		// Only the leading emoji code is of interest, e.g. :sparkles::lipstick:
+		end := strings.Index(subject[1:], ":")
+		if end == -1 {
+			return NoIncrement, noMatchIdx, noMatchIdx
+		}
+
+		code := subject[:end+2]
+		if inc, found := s.Emojis[code]; found {
+			return inc, start, start + len(code)
+		}
+		return NoIncrement, noMatchIdx, noMatchIdx
+	}
+
+	for _, emoji := range s.Unicode {
+		if !strings.HasPrefix(subject, emoji) {
+			continue
+		}
+		inc := s.Emojis[emoji]
+
+		// Include any variation selector that may follow a unicode emoji
+		end := start + len(emoji)
+		if strings.HasPrefix(msg[end:], variationSelector) {
+			end += len(variationSelector)
+		}
+		return inc, start, end
+	}
+
+	return NoIncrement, noMatchIdx, noMatchIdx
+}
@@ -0,0 +1,255 @@
+package nsv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	GoModuleWarn  = "warn"
+	GoModuleFail  = "fail"
+	GoModulePatch = "patch"
+
+	goMod = "go.mod"
+)
+
+var (
+	GoModuleStrategies = []string{GoModuleWarn, GoModuleFail, GoModulePatch}
+
+	majorSuffixRgx = regexp.MustCompile(`/v([0-9]+)$`)
+)
+
+type UnsupportedGoModuleStrategyError struct {
+	Strategy string
+}
+
+func (e UnsupportedGoModuleStrategyError) Error() string {
+	return fmt.Sprintf("go module strategy '%s' is not supported, must be one of either: %s",
+		e.Strategy, strings.Join(GoModuleStrategies, ", "))
+}
+
+type GoModulePathError struct {
+	Module   string
+	Expected string
+	Tag      string
+}
+
+func (e GoModulePathError) Error() string {
+	return fmt.Sprintf("go module path '%s' does not match the major version of tag %s, expected module path '%s'",
+		e.Module, e.Tag, e.Expected)
+}
+
+type GoTagPrefixError struct {
+	Dir    string
+	Prefix string
+}
+
+func (e GoTagPrefixError) Error() string {
+	return fmt.Sprintf("tag prefix '%s' does not match the go module directory '%s', provide a matching prefix using a custom format",
+		e.Prefix, e.Dir)
+}
+
+// CheckGoModuleStrategy ensures the go module strategy is supported
+func CheckGoModuleStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+
+	for _, s := range GoModuleStrategies {
+		if s == strategy {
+			return nil
+		}
+	}
+
+	return UnsupportedGoModuleStrategyError{Strategy: strategy}
+}
+
+// goModulePath reads the module path from the go.mod file within a directory of the
+// working tree. An empty path is returned if the directory does not contain a go module
+func goModulePath(fsys fs.FS, dir string) (string, error) {
+	data, err := fs.ReadFile(fsys, path.Join(dir, goMod))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if path, found := strings.CutPrefix(line, "module "); found {
+			return strings.Trim(strings.TrimSpace(path), `"`), nil
+		}
+	}
+
+	return "", nil
+}
+
+// expectedModulePath returns the module path required by the major version of a
+// tag, see: https://go.dev/ref/mod#major-version-suffixes
+func expectedModulePath(module, semv string) (string, error) {
+	ver, err := semver.StrictNewVersion(semv)
+	if err != nil {
+		return "", err
+	}
+
+	// Modules served from gopkg.in use their own versioning scheme
+	if strings.HasPrefix(module, "gopkg.in/") {
+		return module, nil
+	}
+
+	base := majorSuffixRgx.ReplaceAllString(module, "")
+	if ver.Major() < 2 {
+		return base, nil
+	}
+
+	return base + "/v" + strconv.FormatUint(ver.Major(), 10), nil
+}
+
+// checkGoModule verifies the module path within a go.mod file matches the major
+// version of the next tag. Depending on the strategy, a mismatch will either log
+// a warning, fail, or patch the module path and any import paths. Without a strategy,
+// no check is made
+func checkGoModule(fsys WorkTree, dir string, tag Tag, opts Options) (bool, error) {
+	if opts.GoModule == "" {
+		return false, nil
+	}
+
+	module, err := goModulePath(fsys, dir)
+	if err != nil || module == "" {
+		return false, err
+	}
+
+	// Go expects the tag of a nested module to be prefixed with its directory
+	// from the root of the repository, see: https://go.dev/ref/mod#vcs-version
+	if modDir := filepath.ToSlash(filepath.Clean(dir)); modDir != "." && tag.Prefix != modDir {
+		if opts.GoModule == GoModuleFail {
+			return false, GoTagPrefixError{Dir: modDir, Prefix: tag.Prefix}
+		}
+		opts.Logger.Warn("tag prefix does not match go module directory", "prefix", tag.Prefix, "dir", modDir)
+	}
+
+	expected, err := expectedModulePath(module, tag.SemVer)
+	if err != nil || module == expected {
+		return false, err
+	}
+
+	switch opts.GoModule {
+	case GoModuleFail:
+		return false, GoModulePathError{Module: module, Expected: expected, Tag: tag.Raw}
+	case GoModulePatch:
+		opts.Logger.Info("patching go module path to match major version", "module", module, "patched", expected)
+		return true, patchGoModule(fsys, dir, module, expected)
+	case GoModuleWarn:
+		opts.Logger.Warn("go module path does not match major version of tag", "module", module, "expected", expected, "tag", tag.Raw)
+	}
+
+	return false, nil
+}
+
+// patchGoModule rewrites the module path within the go.mod file and the path of any
+// import spec within go files of the module. Nested modules, vendored dependencies and
+// directories ignored by the go tool are left untouched
+func patchGoModule(fsys WorkTree, dir, module, patched string) error {
+	moduleRgx := regexp.MustCompile(`(?m)^([ \t]*module[ \t]+"?)` + regexp.QuoteMeta(module) + `("?[ \t]*)$`)
+
+	root := path.Clean(dir)
+	modFile := path.Join(root, goMod)
+	if err := rewriteFile(fsys, modFile, func(data []byte) ([]byte, error) {
+		return moduleRgx.ReplaceAll(data, []byte("${1}"+patched+"${2}")), nil
+	}); err != nil {
+		return err
+	}
+
+	return fs.WalkDir(fsys, root, func(pathname string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if pathname == root {
+				return nil
+			}
+
+			if name := d.Name(); name == "vendor" || name == "testdata" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return fs.SkipDir
+			}
+
+			if _, err := fs.Stat(fsys, path.Join(pathname, goMod)); err == nil {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if path.Ext(pathname) != ".go" {
+			return nil
+		}
+
+		return rewriteFile(fsys, pathname, func(src []byte) ([]byte, error) {
+			return patchImports(pathname, src, module, patched)
+		})
+	})
+}
+
+// patchImports replaces the module path within any import spec of a go file
+func patchImports(filename string, src []byte, module, patched string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace from the end of the file, so the offsets of earlier imports remain valid
+	for i := len(file.Imports) - 1; i >= 0; i-- {
+		spec := file.Imports[i]
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		suffix, found := strings.CutPrefix(importPath, module)
+		if !found || (suffix != "" && !strings.HasPrefix(suffix, "/")) {
+			continue
+		}
+
+		start := fset.Position(spec.Path.Pos()).Offset
+		end := fset.Position(spec.Path.End()).Offset
+		src = slices.Concat(src[:start], []byte(strconv.Quote(patched+suffix)), src[end:])
+	}
+
+	return src, nil
+}
+
+// rewriteFile rewrites a file within the working tree, preserving its permissions. The
+// file is only written if its contents change
+func rewriteFile(fsys WorkTree, name string, rewrite func([]byte) ([]byte, error)) error {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	rewritten, err := rewrite(data)
+	if err != nil || bytes.Equal(data, rewritten) {
+		return err
+	}
+
+	return fsys.WriteFile(name, rewritten, info.Mode().Perm())
+}
@@ -0,0 +1,143 @@
+package nsv_test
+
+import (
+	"os"
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goModuleLog = `(main, origin/main) refactor!: rename the search client options
+(tag: v1.2.0) feat: support searching by tags`
+
+func initGoModule(t *testing.T, module string) {
+	t.Helper()
+
+	gittest.InitRepository(t,
+		gittest.WithLog(goModuleLog),
+		gittest.WithCommittedFiles("go.mod", "main.go"),
+		gittest.WithFileContent(
+			"go.mod", "module "+module+"\n\ngo 1.23\n",
+			"main.go", `package main
+
+import "`+module+`/internal/index"
+
+func main() { index.Build() }
+`),
+	)
+}
+
+func TestNextVersionGoModuleWarn(t *testing.T) {
+	initGoModule(t, "github.com/purpleclay/search")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, GoModule: nsv.GoModuleWarn})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "v2.0.0", next.Tag)
+}
+
+func TestNextVersionGoModuleNotChecked(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog(goModuleLog))
+	require.NoError(t, os.Mkdir("search", 0o755))
+	gittest.TempFile(t, "search/go.mod", "module github.com/purpleclay/search\n\ngo 1.23\n")
+	gittest.StageFile(t, "search/go.mod")
+	gittest.Commit(t, "feat: add search module")
+	gitc, _ := git.NewClient()
+
+	opts := nsv.Options{Logger: noopLogger, Path: "search", VersionFormat: "ui/v{{.SemVer}}", GoModule: nsv.GoModuleFail}
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), opts)
+	require.EqualError(t, err, "tag prefix 'ui' does not match the go module directory 'search', "+
+		"provide a matching prefix using a custom format")
+
+	opts.GoModule = ""
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), opts)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "ui/v0.1.0", next.Tag)
+}
+
+func TestNextVersionGoModuleFail(t *testing.T) {
+	initGoModule(t, "github.com/purpleclay/search")
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, GoModule: nsv.GoModuleFail})
+	require.EqualError(t, err, "go module path 'github.com/purpleclay/search' does not match the major version of tag v2.0.0, "+
+		"expected module path 'github.com/purpleclay/search/v2'")
+}
+
+func TestNextVersionGoModuleMatchingSuffix(t *testing.T) {
+	initGoModule(t, "github.com/purpleclay/search/v2")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, GoModule: nsv.GoModuleFail})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "v2.0.0", next.Tag)
+}
+
+func TestNextVersionGoModulePatch(t *testing.T) {
+	initGoModule(t, "github.com/purpleclay/search")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, GoModule: nsv.GoModulePatch})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	paths := make([]string, 0, len(next.Diffs))
+	for _, diff := range next.Diffs {
+		paths = append(paths, diff.Path)
+	}
+	assert.ElementsMatch(t, []string{"go.mod", "main.go"}, paths)
+
+	mod, _ := os.ReadFile("go.mod")
+	assert.Equal(t, "module github.com/purpleclay/search/v2\n\ngo 1.23\n", string(mod))
+
+	src, _ := os.ReadFile("main.go")
+	assert.Contains(t, string(src), `import "github.com/purpleclay/search/v2/internal/index"`)
+}
+
+func TestNextVersionGoModulePatchOnlyImports(t *testing.T) {
+	gittest.InitRepository(t,
+		gittest.WithLog(goModuleLog),
+		gittest.WithCommittedFiles("go.mod", "main.go"),
+		gittest.WithFileContent(
+			"go.mod", "module github.com/purpleclay/search\n\ngo 1.23\n",
+			"main.go", `package main
+
+import (
+	"fmt"
+
+	idx "github.com/purpleclay/search/internal/index"
+	"github.com/purpleclay/searchable"
+)
+
+const docs = "github.com/purpleclay/search/internal/index"
+
+func main() { fmt.Println(docs, idx.Build(), searchable.Enabled) }
+`),
+	)
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, GoModule: nsv.GoModulePatch})
+	require.NoError(t, err)
+
+	src, _ := os.ReadFile("main.go")
+	assert.Equal(t, `package main
+
+import (
+	"fmt"
+
+	idx "github.com/purpleclay/search/v2/internal/index"
+	"github.com/purpleclay/searchable"
+)
+
+const docs = "github.com/purpleclay/search/internal/index"
+
+func main() { fmt.Println(docs, idx.Build(), searchable.Enabled) }
+`, string(src))
+}
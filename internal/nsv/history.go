@@ -0,0 +1,187 @@
+package nsv
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	git "github.com/purpleclay/gitz"
+)
+
+// Release describes a tagged semantic version within the history of a repository
+type Release struct {
+	// Tag is the semantic version tag of the release
+	Tag string
+
+	// PrevTag is the tag of the previous release. It will be empty for the
+	// first release
+	PrevTag string
+
+	// Hash of the commit the tag points to
+	Hash string
+
+	// Date the release was tagged. For a lightweight tag, this is the date
+	// of the tagged commit
+	Date time.Time
+
+	// Commits is the number of commits within the release that affect the
+	// path being versioned
+	Commits int
+
+	// Increment between the previous and current release
+	Increment Increment
+
+	// Tagger identifies who created the release. For a lightweight tag, this
+	// is the committer of the tagged commit
+	Tagger string
+
+	// Message of an annotated tag
+	Message string
+}
+
+// History lists every release of a path, identified by the prefix of its tags,
+// in semantic version order. Only tags reachable from the ref, or HEAD if empty,
+// are listed
+func History(repo Repository, opts Options) ([]Release, error) {
+	if err := checkAndHealRepository(repo, opts); err != nil {
+		return nil, err
+	}
+
+	ctx, err := resolveContext(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := repo.Tags(headIfEmpty(opts.Ref))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := sortedTags(all, ctx.TagPrefix)
+	opts.Logger.Info("identified release tags", "tags", len(tags), "prefix", ctx.TagPrefix)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	details, err := repo.ShowTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(tags))
+	prev := firstVer
+	var prevTag string
+	for _, tag := range tags {
+		log, err := repo.Log(tag, prevTag, ctx.LogPath)
+		if err != nil {
+			return nil, err
+		}
+
+		ver, _ := ParseTag(tag)
+		rel := Release{
+			Tag:       tag,
+			PrevTag:   prevTag,
+			Commits:   len(log),
+			Increment: incrementBetween(semver.MustParse(prev), semver.MustParse(ver.SemVer)),
+		}
+		describeRelease(&rel, details[tag])
+
+		releases = append(releases, rel)
+		prev = ver.SemVer
+		prevTag = tag
+	}
+
+	return releases, nil
+}
+
+func describeRelease(rel *Release, details git.TagDetails) {
+	rel.Hash = details.Commit.Ref
+	rel.Date = details.Commit.CommitterDate
+	rel.Tagger = person(details.Commit.Committer)
+
+	if details.Annotation != nil {
+		rel.Date = details.Annotation.TaggerDate
+		rel.Tagger = person(details.Annotation.Tagger)
+		rel.Message = details.Annotation.Message
+	}
+}
+
+func person(p git.Person) string {
+	if p.Name == "" {
+		return p.Email
+	}
+
+	if p.Email == "" {
+		return p.Name
+	}
+
+	return p.Name + " <" + p.Email + ">"
+}
+
+// sortedTags filters a list of tags to those with an exact prefix, sorted in
+// ascending semantic version order. Any tag that is not a semantic version is
+// discarded
+func sortedTags(tags []string, prefix string) []string {
+	type semverTag struct {
+		raw string
+		ver *semver.Version
+	}
+
+	var filtered []semverTag
+	for _, raw := range tags {
+		tag, err := ParseTag(raw)
+		if err != nil || tag.Prefix != prefix {
+			continue
+		}
+
+		ver, _ := semver.StrictNewVersion(tag.SemVer)
+		filtered = append(filtered, semverTag{raw: raw, ver: ver})
+	}
+
+	slices.SortStableFunc(filtered, func(a, b semverTag) int {
+		return a.ver.Compare(b.ver)
+	})
+
+	sorted := make([]string, 0, len(filtered))
+	for _, t := range filtered {
+		sorted = append(sorted, t.raw)
+	}
+	return sorted
+}
+
+// AppliedIncrement identifies the increment between the previous and next tag. It can
+// differ from the detected increment, such as when raised to a minimum version or when
+// releasing an initial version. The detected increment is returned if either tag is not
+// a semantic version, typically due to a custom format
+func AppliedIncrement(next *Next) Increment {
+	if next.PrevTag == "" || next.Tag == "" {
+		return next.Increment
+	}
+
+	prev, err := ParseTag(next.PrevTag)
+	if err != nil {
+		return next.Increment
+	}
+
+	tag, err := ParseTag(next.Tag)
+	if err != nil {
+		return next.Increment
+	}
+
+	return incrementBetween(semver.MustParse(prev.SemVer), semver.MustParse(tag.SemVer))
+}
+
+// incrementBetween identifies the largest part of a semantic version that changed
+// between two versions. Versions that differ only by prerelease have no increment
+func incrementBetween(prev, next *semver.Version) Increment {
+	switch {
+	case next.Major() != prev.Major():
+		return MajorIncrement
+	case next.Minor() != prev.Minor():
+		return MinorIncrement
+	case next.Patch() != prev.Patch():
+		return PatchIncrement
+	default:
+		return NoIncrement
+	}
+}
@@ -0,0 +1,142 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("v0.1.0", "", "chore: tagged release v0.1.0"))
+	repo.Commit("fix: search results not sorted", "search.go")
+	repo.Commit("docs: document search", "README.md")
+	require.NoError(t, repo.Tag("v0.1.1", "", ""))
+	repo.Commit("feat!: drop support for the v1 search api", "search.go")
+	require.NoError(t, repo.Tag("v1.0.0", "", "chore: tagged release v1.0.0"))
+	require.NoError(t, repo.Tag("v1.0.0-beta.1", "", ""))
+	require.NoError(t, repo.Tag("latest", "", ""))
+
+	releases, err := nsv.History(repo, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.Len(t, releases, 4)
+
+	assert.Equal(t, "v0.1.0", releases[0].Tag)
+	assert.Empty(t, releases[0].PrevTag)
+	assert.Equal(t, nsv.MinorIncrement, releases[0].Increment)
+	assert.Equal(t, 1, releases[0].Commits)
+	assert.Equal(t, "chore: tagged release v0.1.0", releases[0].Message)
+	assert.Equal(t, "nsv@example.com", releases[0].Tagger)
+
+	assert.Equal(t, "v0.1.1", releases[1].Tag)
+	assert.Equal(t, nsv.PatchIncrement, releases[1].Increment)
+	assert.Equal(t, 2, releases[1].Commits)
+	assert.Empty(t, releases[1].Message)
+
+	assert.Equal(t, "v1.0.0-beta.1", releases[2].Tag)
+	assert.Equal(t, nsv.MajorIncrement, releases[2].Increment)
+
+	assert.Equal(t, "v1.0.0", releases[3].Tag)
+	assert.Equal(t, "v1.0.0-beta.1", releases[3].PrevTag)
+	assert.Equal(t, nsv.NoIncrement, releases[3].Increment)
+}
+
+func TestHistoryWithPath(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat(ui): initial search ui", "src/ui/index.ts")
+	require.NoError(t, repo.Tag("ui/0.1.0", "", ""))
+	repo.Commit("feat(search): support searching by tags", "src/search/search.go")
+	require.NoError(t, repo.Tag("search/0.1.0", "", ""))
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("fix(ui): dark mode toggle not persisted", "src/ui/theme.ts")
+	require.NoError(t, repo.Tag("ui/0.1.1", "", ""))
+
+	releases, err := nsv.History(repo, nsv.Options{Logger: noopLogger, Path: "src/ui"})
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+
+	assert.Equal(t, "ui/0.1.0", releases[0].Tag)
+	assert.Equal(t, "ui/0.1.1", releases[1].Tag)
+	assert.Equal(t, 1, releases[1].Commits)
+}
+
+func TestHistoryNoTags(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+
+	releases, err := nsv.History(repo, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+}
+
+func TestHistoryAnnotatedTag(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog("(tag: 0.1.0) feat: initial search support"))
+	gittest.ConfigSet(t, "user.name", "batman", "user.email", "batman@dc.com")
+	gittest.MustExec(t, `git commit --allow-empty -m "feat: support pagination of search results"`)
+	gittest.MustExec(t, `git tag -a 0.2.0 -m "chore: tagged release 0.2.0"`)
+
+	gitc, _ := git.NewClient()
+	releases, err := nsv.History(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+
+	assert.Equal(t, "0.2.0", releases[1].Tag)
+	assert.Equal(t, "batman <batman@dc.com>", releases[1].Tagger)
+	assert.Equal(t, "chore: tagged release 0.2.0", releases[1].Message)
+	assert.False(t, releases[1].Date.IsZero())
+	assert.NotEmpty(t, releases[1].Hash)
+}
+
+func TestHistoryOnlyReachableFromHead(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog("(tag: 0.1.0) feat: initial search support"))
+	gittest.MustExec(t, "git checkout -b feature")
+	gittest.MustExec(t, `git commit --allow-empty -m "feat: support pagination of search results"`)
+	gittest.MustExec(t, "git tag 0.2.0")
+	gittest.MustExec(t, "git checkout "+gittest.DefaultBranch)
+
+	gitc, _ := git.NewClient()
+	releases, err := nsv.History(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "0.1.0", releases[0].Tag)
+}
+
+func TestAppliedIncrement(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     nsv.Next
+		expected nsv.Increment
+	}{
+		{
+			name:     "Detected",
+			next:     nsv.Next{PrevTag: "v0.1.0", Tag: "v0.2.0", Increment: nsv.MinorIncrement},
+			expected: nsv.MinorIncrement,
+		},
+		{
+			name:     "RaisedToMinVersion",
+			next:     nsv.Next{PrevTag: "0.1.0", Tag: "1.0.0", Increment: nsv.PatchIncrement},
+			expected: nsv.MajorIncrement,
+		},
+		{
+			name:     "WithPrefix",
+			next:     nsv.Next{PrevTag: "ui/0.1.0", Tag: "ui/0.1.1", Increment: nsv.MinorIncrement},
+			expected: nsv.PatchIncrement,
+		},
+		{
+			name:     "CustomFormat",
+			next:     nsv.Next{PrevTag: "ui@0.1.0", Tag: "ui@0.2.0", Increment: nsv.MinorIncrement},
+			expected: nsv.MinorIncrement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nsv.AppliedIncrement(&tt.next))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package nsv
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+// GitLab lists the changes within the body of a merge commit, so a merge is only
+// ignored by default if commit bodies are not being parsed
+const mergeBranchIgnore = `^Merge branch `
+
+var (
+	// Commits generated by git when preparing to autosquash, or merging a branch
+	// locally, carry no meaningful intent for versioning
+	defaultIgnoreMessages = []string{
+		`^fixup! `,
+		`^squash! `,
+		`^amend! `,
+		mergeBranchIgnore,
+		`^(?i)wip\b`,
+	}
+
+	// Dependency bots generate conventional commits that would otherwise trigger
+	// a release of every package they touch
+	defaultIgnoreAuthors = []string{
+		`(?i)\[bot\]@users\.noreply\.github\.com$`,
+		`(?i)^bot@renovateapp\.com$`,
+		`(?i)^renovate(-bot)?@`,
+		`(?i)^dependabot`,
+	}
+)
+
+type InvalidIgnorePatternError struct {
+	Pattern string
+	Err     error
+}
+
+func (e InvalidIgnorePatternError) Error() string {
+	return fmt.Sprintf("ignore pattern '%s' is not a valid regular expression: %s", e.Pattern, e.Err)
+}
+
+// Ignored identifies a commit by its index within the log that should not take
+// part in detecting the next increment, along with the reason it was ignored
+type Ignored struct {
+	Index  int
+	Reason string
+}
+
+// IgnoreRules contains the regular expressions used to identify commits that
+// should be ignored, either by their message or author email
+type IgnoreRules struct {
+	Authors  []*regexp.Regexp
+	Messages []*regexp.Regexp
+}
+
+// NewIgnoreRules compiles the provided patterns into a set of ignore rules. Unless
+// disabled, they are appended to the built-in rules that ignore fixup!, squash!,
+// amend!, merge branch, WIP and dependency bot commits
+func NewIgnoreRules(messages, authors []string, noDefaults bool) (IgnoreRules, error) {
+	if !noDefaults {
+		messages = append(append([]string{}, defaultIgnoreMessages...), messages...)
+		authors = append(append([]string{}, defaultIgnoreAuthors...), authors...)
+	}
+
+	var rules IgnoreRules
+	var err error
+	if rules.Messages, err = compileIgnorePatterns(messages); err != nil {
+		return IgnoreRules{}, err
+	}
+
+	if rules.Authors, err = compileIgnorePatterns(authors); err != nil {
+		return IgnoreRules{}, err
+	}
+
+	return rules, nil
+}
+
+// ignoreRules compiles the ignore rules from the options. The built-in rule for
+// ignoring merge branch commits is dropped when parsing commit bodies
+func ignoreRules(opts Options) (IgnoreRules, error) {
+	if opts.NoDefaultIgnores || !opts.ParseBody {
+		return NewIgnoreRules(opts.IgnoreCommits, opts.IgnoreAuthors, opts.NoDefaultIgnores)
+	}
+
+	messages := slices.DeleteFunc(slices.Clone(defaultIgnoreMessages), func(pattern string) bool {
+		return pattern == mergeBranchIgnore
+	})
+	return NewIgnoreRules(append(messages, opts.IgnoreCommits...), append(slices.Clone(defaultIgnoreAuthors), opts.IgnoreAuthors...), true)
+}
+
+func compileIgnorePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, InvalidIgnorePatternError{Pattern: pattern, Err: err}
+		}
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// DetectIgnored scans the log for any commits that match the ignore rules. Authors
+// are identified by mapping the hash of each commit to its author email
+func (r IgnoreRules) DetectIgnored(log []git.LogEntry, authors map[string]string) []Ignored {
+	var ignored []Ignored
+
+	for i, entry := range log {
+		if reason := r.match(entry, authors[entry.Hash]); reason != "" {
+			ignored = append(ignored, Ignored{Index: i, Reason: reason})
+		}
+	}
+
+	return ignored
+}
+
+func (r IgnoreRules) match(entry git.LogEntry, author string) string {
+	for _, re := range r.Messages {
+		if loc := re.FindStringIndex(entry.Message); loc != nil {
+			return strings.TrimSpace(entry.Message[loc[0]:loc[1]])
+		}
+	}
+
+	if author == "" {
+		return ""
+	}
+
+	for _, re := range r.Authors {
+		if re.MatchString(author) {
+			return author
+		}
+	}
+
+	return ""
+}
+
+func detectIgnored(repo Repository, log []git.LogEntry, from, path string, opts Options) ([]Ignored, error) {
+	rules, err := ignoreRules(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var authors map[string]string
+	if len(rules.Authors) > 0 && len(log) > 0 {
+		if authors, err = repo.Authors(opts.Ref, from, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules.DetectIgnored(log, authors), nil
+}
@@ -0,0 +1,88 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectIgnored(t *testing.T) {
+	t.Parallel()
+
+	log := []git.LogEntry{
+		{Hash: "a1", Message: "fixup! feat: support pagination of search results"},
+		{Hash: "b2", Message: "squash! fix: search results are not sorted by relevance"},
+		{Hash: "c3", Message: "amend! docs: document new pagination improvements"},
+		{Hash: "d4", Message: "Merge branch 'main' into feat/search"},
+		{Hash: "e5", Message: "WIP: pagination"},
+		{Hash: "f6", Message: "fix(deps): update module github.com/charmbracelet/log to v0.4.2"},
+		{Hash: "g7", Message: "chore(deps): bump actions/checkout from 3 to 4"},
+		{Hash: "h8", Message: "feat: support pagination of search results"},
+	}
+	authors := map[string]string{
+		"f6": "29139614+renovate[bot]@users.noreply.github.com",
+		"g7": "49699333+dependabot[bot]@users.noreply.github.com",
+		"h8": "batman@dc.com",
+	}
+
+	rules, err := nsv.NewIgnoreRules(nil, nil, false)
+	require.NoError(t, err)
+
+	ignored := rules.DetectIgnored(log, authors)
+	assert.Equal(t, []nsv.Ignored{
+		{Index: 0, Reason: "fixup!"},
+		{Index: 1, Reason: "squash!"},
+		{Index: 2, Reason: "amend!"},
+		{Index: 3, Reason: "Merge branch"},
+		{Index: 4, Reason: "WIP"},
+		{Index: 5, Reason: "29139614+renovate[bot]@users.noreply.github.com"},
+		{Index: 6, Reason: "49699333+dependabot[bot]@users.noreply.github.com"},
+	}, ignored)
+}
+
+func TestDetectIgnoredUserDefined(t *testing.T) {
+	t.Parallel()
+
+	log := []git.LogEntry{
+		{Hash: "a1", Message: "fixup! feat: support pagination of search results"},
+		{Hash: "b2", Message: "ci: [skip release] add parallel testing support"},
+		{Hash: "c3", Message: "fix: search results are not sorted by relevance"},
+	}
+	authors := map[string]string{
+		"c3": "release-bot@example.com",
+	}
+
+	rules, err := nsv.NewIgnoreRules([]string{`\[skip release\]`}, []string{`^release-bot@`}, true)
+	require.NoError(t, err)
+
+	ignored := rules.DetectIgnored(log, authors)
+	assert.Equal(t, []nsv.Ignored{
+		{Index: 1, Reason: "[skip release]"},
+		{Index: 2, Reason: "release-bot@example.com"},
+	}, ignored)
+}
+
+func TestNewIgnoreRulesInvalidPattern(t *testing.T) {
+	_, err := nsv.NewIgnoreRules([]string{"^wip("}, nil, false)
+	require.ErrorContains(t, err, "ignore pattern '^wip(' is not a valid regular expression")
+}
+
+func TestNextVersionParseBodyOfMergeBranch(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit(`Merge branch 'pagination' into 'main'
+
+- feat: support pagination of search results
+- fix: search results not sorted`, "search.go")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger, ParseBody: true})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "0.2.0", next.Tag)
+	assert.Empty(t, next.Ignored)
+}
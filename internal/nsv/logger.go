@@ -0,0 +1,18 @@
+package nsv
+
+// Logger records the decisions made when calculating the next semantic version.
+// It is satisfied by a *log.Logger from github.com/charmbracelet/log
+type Logger interface {
+	Debug(msg interface{}, keyvals ...interface{})
+	Info(msg interface{}, keyvals ...interface{})
+	Warn(msg interface{}, keyvals ...interface{})
+}
+
+// NoopLogger discards all log output
+type NoopLogger struct{}
+
+func (NoopLogger) Debug(_ interface{}, _ ...interface{}) {}
+
+func (NoopLogger) Info(_ interface{}, _ ...interface{}) {}
+
+func (NoopLogger) Warn(_ interface{}, _ ...interface{}) {}
@@ -0,0 +1,376 @@
+package nsv
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	git "github.com/purpleclay/gitz"
+)
+
+type UnknownRefError struct {
+	Ref string
+}
+
+func (e UnknownRefError) Error() string {
+	return fmt.Sprintf("ref '%s' does not exist within the repository", e.Ref)
+}
+
+type memoryCommit struct {
+	entry  git.LogEntry
+	author string
+	date   time.Time
+	paths  []string
+}
+
+type memoryTag struct {
+	hash       string
+	annotation *git.TagAnnotation
+}
+
+// MemoryRepository is an in-memory [Repository] with a linear history. It is
+// intended for testing, or calculating versions where no git binary exists
+type MemoryRepository struct {
+	// Author is the email used for all subsequent commits
+	Author string
+
+	// Dir is the path of the current working directory relative to the root
+	// of the repository
+	Dir string
+
+	// Changes contains any uncommitted changes, reported by [MemoryRepository.Diff]
+	Changes []git.FileDiff
+
+	// Files contains the working tree, relative to the current working directory.
+	// Patching a go module writes to these files, but running a hook still changes
+	// the files on disk
+	Files fstest.MapFS
+
+	commits []memoryCommit
+	config  map[string]string
+	tags    map[string]memoryTag
+	remote  map[string]string
+	pushed  []string
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		Author: "nsv@example.com",
+		Dir:    git.RelativeAtRoot,
+		Files:  fstest.MapFS{},
+		config: map[string]string{},
+		tags:   map[string]memoryTag{},
+		remote: map[string]string{},
+	}
+}
+
+func (r *MemoryRepository) Info() (git.Repository, error) {
+	return git.Repository{Ref: "main", DefaultBranch: "main"}, nil
+}
+
+func (r *MemoryRepository) Unshallow() error {
+	return nil
+}
+
+func (r *MemoryRepository) RelativePath() (string, error) {
+	return r.Dir, nil
+}
+
+func (r *MemoryRepository) WorkTree() WorkTree {
+	return memoryWorkTree{repo: r}
+}
+
+// memoryWorkTree is a [WorkTree] backed by the files of a [MemoryRepository]. Writing
+// to a file records it as an uncommitted change
+type memoryWorkTree struct {
+	repo *MemoryRepository
+}
+
+func (w memoryWorkTree) Open(name string) (fs.File, error) {
+	return w.repo.Files.Open(name)
+}
+
+func (w memoryWorkTree) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if file, exists := w.repo.Files[name]; exists {
+		perm = file.Mode
+	}
+	w.repo.Files[name] = &fstest.MapFile{Data: data, Mode: perm}
+
+	changed := path.Join(w.repo.Dir, name)
+	if !slices.ContainsFunc(w.repo.Changes, func(diff git.FileDiff) bool { return diff.Path == changed }) {
+		w.repo.Changes = append(w.repo.Changes, git.FileDiff{Path: changed})
+	}
+	return nil
+}
+
+func (r *MemoryRepository) Tags(ref string) ([]string, error) {
+	reachable := len(r.commits) - 1
+	if ref != "" {
+		var err error
+		if reachable, err = r.index(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	tags := make([]string, 0, len(r.tags))
+	for tag, t := range r.tags {
+		if idx, _ := r.index(t.hash); idx <= reachable {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func (r *MemoryRepository) RemoteTags() ([]string, error) {
+	tags := make([]string, 0, len(r.remote))
+	for tag := range r.remote {
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (r *MemoryRepository) Log(ref, from, path string) ([]git.LogEntry, error) {
+	commits, err := r.log(ref, from, path)
+	if err != nil {
+		return nil, err
+	}
+
+	log := make([]git.LogEntry, 0, len(commits))
+	for _, commit := range commits {
+		log = append(log, commit.entry)
+	}
+	return log, nil
+}
+
+// MergeLog is identical to [MemoryRepository.Log], as the history is always linear
+func (r *MemoryRepository) MergeLog(ref, from, path string) ([]git.LogEntry, error) {
+	return r.Log(ref, from, path)
+}
+
+func (r *MemoryRepository) Authors(ref, from, path string) (map[string]string, error) {
+	commits, err := r.log(ref, from, path)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make(map[string]string, len(commits))
+	for _, commit := range commits {
+		authors[commit.entry.Hash] = commit.author
+	}
+	return authors, nil
+}
+
+func (r *MemoryRepository) log(ref, from, dir string) ([]memoryCommit, error) {
+	if len(r.commits) == 0 {
+		return nil, nil
+	}
+
+	start := len(r.commits) - 1
+	if ref != "" {
+		var err error
+		if start, err = r.index(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	stop := -1
+	if from != "" {
+		var err error
+		if stop, err = r.index(from); err != nil {
+			return nil, err
+		}
+	}
+
+	var commits []memoryCommit
+	for i := start; i > stop; i-- {
+		if touchesPath(r.commits[i].paths, dir) {
+			commits = append(commits, r.commits[i])
+		}
+	}
+
+	return commits, nil
+}
+
+// index resolves a ref to the position of its commit within the history
+func (r *MemoryRepository) index(ref string) (int, error) {
+	hash, err := r.resolve(ref)
+	if err != nil {
+		return -1, err
+	}
+
+	return slices.IndexFunc(r.commits, func(c memoryCommit) bool {
+		return c.entry.Hash == hash
+	}), nil
+}
+
+func touchesPath(paths []string, dir string) bool {
+	if dir == "" || dir == git.RelativeAtRoot {
+		return true
+	}
+
+	dir = path.Clean(dir)
+	for _, p := range paths {
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryRepository) resolve(ref string) (string, error) {
+	if ref == git.HeadRef {
+		if len(r.commits) == 0 {
+			return "", UnknownRefError{Ref: ref}
+		}
+		return r.commits[len(r.commits)-1].entry.Hash, nil
+	}
+
+	if t, found := r.tags[ref]; found {
+		return t.hash, nil
+	}
+
+	for _, commit := range r.commits {
+		if strings.HasPrefix(commit.entry.Hash, ref) {
+			return commit.entry.Hash, nil
+		}
+	}
+
+	return "", UnknownRefError{Ref: ref}
+}
+
+func (r *MemoryRepository) ChangedFiles(from, to, path string) ([]string, error) {
+	commits, err := r.log(to, from, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, commit := range commits {
+		for _, p := range commit.paths {
+			if touchesPath([]string{p}, path) && !slices.Contains(files, p) {
+				files = append(files, p)
+			}
+		}
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
+
+func (r *MemoryRepository) Diff() ([]git.FileDiff, error) {
+	return r.Changes, nil
+}
+
+func (r *MemoryRepository) Config() (map[string]string, error) {
+	return r.config, nil
+}
+
+// SetConfig sets a git config value for the repository
+func (r *MemoryRepository) SetConfig(key, value string) {
+	r.config[key] = value
+}
+
+// Commit appends a commit to the history of the repository, recording the
+// paths it changes. Any uncommitted changes to those paths are discarded
+func (r *MemoryRepository) Commit(msg string, paths ...string) (string, error) {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%d\x00%s\x00%s", len(r.commits), msg, strings.Join(paths, "\x00"))))
+	hash := hex.EncodeToString(sum[:])
+
+	r.commits = append(r.commits, memoryCommit{
+		entry:  git.LogEntry{Hash: hash, AbbrevHash: hash[:7], Message: msg},
+		author: r.Author,
+		date:   time.Now(),
+		paths:  paths,
+	})
+
+	var changes []git.FileDiff
+	for _, change := range r.Changes {
+		if !slices.Contains(paths, change.Path) {
+			changes = append(changes, change)
+		}
+	}
+	r.Changes = changes
+
+	return hash, nil
+}
+
+func (r *MemoryRepository) ShowTags(tags ...string) (map[string]git.TagDetails, error) {
+	details := make(map[string]git.TagDetails, len(tags))
+	for _, tag := range tags {
+		t, found := r.tags[tag]
+		if !found {
+			return nil, UnknownRefError{Ref: tag}
+		}
+
+		idx, _ := r.index(t.hash)
+		commit := r.commits[idx]
+		author := git.Person{Email: commit.author}
+
+		details[tag] = git.TagDetails{
+			Annotation: t.annotation,
+			Commit: git.CommitDetails{
+				Author:        author,
+				AuthorDate:    commit.date,
+				Committer:     author,
+				CommitterDate: commit.date,
+				Message:       commit.entry.Message,
+				Ref:           commit.entry.Hash,
+			},
+			Ref: tag,
+		}
+	}
+	return details, nil
+}
+
+// Tag creates a tag pointing to a ref, or HEAD if empty. The current author is
+// recorded as the tagger of an annotated tag
+func (r *MemoryRepository) Tag(tag, ref, annotation string) error {
+	if ref == "" {
+		ref = git.HeadRef
+	}
+
+	hash, err := r.resolve(ref)
+	if err != nil {
+		return err
+	}
+
+	t := memoryTag{hash: hash}
+	if annotation != "" {
+		t.annotation = &git.TagAnnotation{
+			Tagger:     git.Person{Email: r.Author},
+			TaggerDate: time.Now(),
+			Message:    annotation,
+		}
+	}
+
+	r.tags[tag] = t
+	return nil
+}
+
+// Push marks any pushed tags as existing on the push remote
+func (r *MemoryRepository) Push(refs ...string) error {
+	for _, ref := range refs {
+		if t, found := r.tags[ref]; found {
+			r.remote[ref] = t.hash
+		}
+	}
+
+	r.pushed = append(r.pushed, refs...)
+	return nil
+}
+
+// Pushed lists all refs pushed to the remote, in the order they were pushed
+func (r *MemoryRepository) Pushed() []string {
+	return r.pushed
+}
@@ -0,0 +1,103 @@
+package nsv_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepositoryNextVersion(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+	repo.Commit("feat: support pagination of search results", "search.go")
+	repo.Commit("docs: document pagination", "README.md")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "0.2.0", next.Tag)
+	assert.Equal(t, "0.1.0", next.PrevTag)
+	require.Len(t, next.Log, 2)
+	assert.Equal(t, 1, next.Match.Index)
+}
+
+func TestMemoryRepositoryNextVersionWithPath(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat(ui): initial search ui", "src/ui/index.ts")
+	require.NoError(t, repo.Tag("ui/0.1.0", "", ""))
+	repo.Commit("feat(search): support searching by tags", "src/search/search.go")
+	repo.Commit("fix(ui): dark mode toggle not persisted", "src/ui/theme.ts")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger, Path: "src/ui"})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "ui/0.1.1", next.Tag)
+	assert.Len(t, next.Log, 1)
+}
+
+func TestMemoryRepositoryNextVersionDetectsLanguage(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Dir = "charts/search"
+	repo.Files = fstest.MapFS{
+		"Chart.yaml": {Data: []byte("apiVersion: v2\nname: search\n")},
+	}
+	repo.Commit("feat: initial search chart", "charts/search/Chart.yaml")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "search-0.1.0", next.Tag)
+	assert.Equal(t, ".", next.LogDir)
+}
+
+func TestMemoryRepositoryNextVersionPatchesGoModule(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Files = fstest.MapFS{
+		"go.mod":  {Data: []byte("module github.com/purpleclay/search\n\ngo 1.23\n")},
+		"main.go": {Data: []byte("package main\n\nimport \"github.com/purpleclay/search/internal/index\"\n")},
+	}
+	repo.Commit("feat: support searching by tags", "go.mod", "main.go")
+	require.NoError(t, repo.Tag("v1.2.0", "", ""))
+	repo.Commit("refactor!: rename the search client options", "main.go")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger, GoModule: nsv.GoModulePatch})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "v2.0.0", next.Tag)
+	assert.Equal(t, "module github.com/purpleclay/search/v2\n\ngo 1.23\n", string(repo.Files["go.mod"].Data))
+	assert.Equal(t, "package main\n\nimport \"github.com/purpleclay/search/v2/internal/index\"\n", string(repo.Files["main.go"].Data))
+
+	paths := make([]string, 0, len(next.Diffs))
+	for _, diff := range next.Diffs {
+		paths = append(paths, diff.Path)
+	}
+	assert.ElementsMatch(t, []string{"go.mod", "main.go"}, paths)
+}
+
+func TestMemoryRepositoryNextVersionIgnoresAuthor(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search support", "search.go")
+	require.NoError(t, repo.Tag("0.1.0", "", ""))
+
+	repo.Author = "29139614+renovate[bot]@users.noreply.github.com"
+	repo.Commit("fix(deps): update module github.com/charmbracelet/log to v0.4.2", "go.mod")
+
+	next, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func TestMemoryRepositoryUnknownRef(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+
+	err := repo.Tag("0.1.0", "", "")
+	require.EqualError(t, err, "ref 'HEAD' does not exist within the repository")
+}
@@ -0,0 +1,69 @@
+package nsv
+
+import (
+	"regexp"
+
+	git "github.com/purpleclay/gitz"
+)
+
+// RegexStrategy detects increments from commits using user-defined regular
+// expressions. Each expression is matched against the entire commit message
+// and will be ignored if empty
+type RegexStrategy struct {
+	Major *regexp.Regexp
+	Minor *regexp.Regexp
+	Patch *regexp.Regexp
+}
+
+// Regex compiles the provided patterns into a strategy
+func Regex(major, minor, patch string) (RegexStrategy, error) {
+	var s RegexStrategy
+	var err error
+
+	if s.Major, err = compileOpt(major); err != nil {
+		return RegexStrategy{}, err
+	}
+
+	if s.Minor, err = compileOpt(minor); err != nil {
+		return RegexStrategy{}, err
+	}
+
+	if s.Patch, err = compileOpt(patch); err != nil {
+		return RegexStrategy{}, err
+	}
+
+	return s, nil
+}
+
+func compileOpt(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	return regexp.Compile(pattern)
+}
+
+func (s RegexStrategy) DetectIncrement(log []git.LogEntry) (Increment, Match) {
+	return detectHighest(log, s.detectMessage)
+}
+
+func (s RegexStrategy) detectMessage(msg string) (Increment, int, int) {
+	for _, rule := range []struct {
+		re  *regexp.Regexp
+		inc Increment
+	}{
+		{s.Major, MajorIncrement},
+		{s.Minor, MinorIncrement},
+		{s.Patch, PatchIncrement},
+	} {
+		if rule.re == nil {
+			continue
+		}
+
+		if loc := rule.re.FindStringIndex(msg); loc != nil {
+			return rule.inc, loc[0], loc[1]
+		}
+	}
+
+	return NoIncrement, noMatchIdx, noMatchIdx
+}
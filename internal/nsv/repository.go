@@ -1,21 +1,101 @@
 package nsv
 
-import git "github.com/purpleclay/gitz"
+import (
+	"io/fs"
 
-func checkAndHealRepository(gitc *git.Client, opts Options) error {
-	repo, err := gitc.Repository()
+	git "github.com/purpleclay/gitz"
+)
+
+// WorkTree is a file system of the working tree that can be written to, allowing
+// files to be patched with the next semantic version
+type WorkTree interface {
+	fs.FS
+
+	// WriteFile writes data to a named file, creating it with the given permissions
+	// if needed. The name is a slash-separated path, as accepted by [fs.ValidPath]
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// Repository provides access to all of the git operations needed to calculate
+// and release the next semantic version. This decouples nsv from the git binary,
+// allowing alternative backends, such as an in-memory repository for testing
+type Repository interface {
+	// Info summarizes the state of the repository, such as if it is a shallow clone
+	Info() (git.Repository, error)
+
+	// Unshallow restores the history and tags of a shallow clone
+	Unshallow() error
+
+	// RelativePath resolves the current working directory relative to the root
+	// of the repository
+	RelativePath() (string, error)
+
+	// WorkTree provides access to the files of the working tree, rooted at the
+	// current working directory
+	WorkTree() WorkTree
+
+	// Tags lists all tags reachable from a ref. All tags within the repository
+	// are listed if the ref is empty
+	Tags(ref string) ([]string, error)
+
+	// RemoteTags lists all tags on the push remote. An empty list is returned if
+	// the repository has no push remote
+	RemoteTags() ([]string, error)
+
+	// Log retrieves all commits between two refs, that affect a given path. The log
+	// starts from HEAD if ref is empty, and covers the entire history if from is empty.
+	// Commits are ordered from newest to oldest
+	Log(ref, from, path string) ([]git.LogEntry, error)
+
+	// MergeLog retrieves the same commits as [Log], but follows the full history of
+	// any merge. Merge commits are never simplified away, and each appears before the
+	// commits it merged
+	MergeLog(ref, from, path string) ([]git.LogEntry, error)
+
+	// Authors retrieves the author email for each commit returned by [Log],
+	// keyed by its hash
+	Authors(ref, from, path string) (map[string]string, error)
+
+	// ChangedFiles lists all files changed between two refs, that are within a given
+	// path. All files within the to ref are listed if from is empty. Paths are relative
+	// to the root of the repository
+	ChangedFiles(from, to, path string) ([]string, error)
+
+	// Diff identifies any uncommitted changes within the repository
+	Diff() ([]git.FileDiff, error)
+
+	// Config retrieves all git config for the repository
+	Config() (map[string]string, error)
+
+	// Commit stages and commits a list of paths, returning the hash of the commit
+	Commit(msg string, paths ...string) (string, error)
+
+	// ShowTags retrieves details about each tag and the commit it points to, keyed
+	// by its name. Only annotated tags contain an annotation
+	ShowTags(tags ...string) (map[string]git.TagDetails, error)
+
+	// Tag creates a tag pointing to a ref, or HEAD if empty. An annotated tag is
+	// created if an annotation is provided, otherwise the tag is lightweight
+	Tag(tag, ref, annotation string) error
+
+	// Push pushes a list of refs to the push remote
+	Push(refs ...string) error
+}
+
+func checkAndHealRepository(repo Repository, opts Options) error {
+	info, err := repo.Info()
 	if err != nil {
 		return err
 	}
-	opts.Logger.Debug("repository summary", "detached", repo.DetachedHead, "shallow",
-		repo.ShallowClone, "ref", repo.Ref)
+	opts.Logger.Debug("repository summary", "detached", info.DetachedHead, "shallow",
+		info.ShallowClone, "ref", info.Ref)
 
-	if repo.ShallowClone {
-		opts.Logger.Warn("repository is a shallow clone and history may be missing", "depth", repo.CloneDepth)
+	if info.ShallowClone {
+		opts.Logger.Warn("repository is a shallow clone and history may be missing", "depth", info.CloneDepth)
 
 		if opts.FixShallow {
 			opts.Logger.Info("fixing shallow clone by restoring history and tags")
-			if _, err := gitc.Fetch(git.WithUnshallow(), git.WithTags()); err != nil {
+			if err := repo.Unshallow(); err != nil {
 				return err
 			}
 
@@ -21,9 +21,42 @@ ci: add parallel testing support to workflow`
 	gittest.InitRepository(t, gittest.WithLog(log), gittest.WithCloneDepth(1))
 
 	client, _ := git.NewClient()
-	err := checkAndHealRepository(client, Options{FixShallow: true, Logger: noopLogger})
+	err := checkAndHealRepository(NewGitRepository(client), Options{FixShallow: true, Logger: noopLogger})
 	require.NoError(t, err)
 
 	logEntries := gittest.Log(t)
 	assert.Len(t, logEntries, 5)
 }
+
+func TestGitRepositoryWithConfig(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog("(main) feat: support searching by tags"))
+	gittest.TempFile(t, "VERSION", "0.1.0")
+
+	client, _ := git.NewClient()
+	repo := NewGitRepository(client).WithConfig("user.name", "joker", "user.email", "joker@dc.com")
+
+	hash, err := repo.Commit("chore: patched files for release 0.1.0", "VERSION")
+	require.NoError(t, err)
+	require.NoError(t, repo.Tag("0.1.0", hash, "chore: tagged release 0.1.0"))
+
+	assert.Equal(t, "joker <joker@dc.com>", gittest.MustExec(t, "git log -1 --pretty=format:'%cn <%ce>'"))
+	assert.Equal(t, "joker <joker@dc.com>", gittest.MustExec(t, "git for-each-ref --format='%(taggername) %(taggeremail)' refs/tags/0.1.0"))
+}
+
+func TestGitRepositoryPushRemoteFromConfig(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog("(main, origin/main) feat: support searching by tags"))
+	mirror := t.TempDir()
+	gittest.MustExec(t, "git init --bare "+mirror)
+	gittest.MustExec(t, "git remote add mirror "+mirror)
+	gittest.ConfigSet(t, "remote.pushDefault", "mirror")
+	gittest.MustExec(t, "git tag 0.1.0")
+
+	client, _ := git.NewClient()
+	repo := NewGitRepository(client)
+	require.NoError(t, repo.Push("0.1.0"))
+
+	tags, err := repo.RemoteTags()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.1.0"}, tags)
+	assert.NotContains(t, gittest.RemoteTags(t), "0.1.0")
+}
@@ -0,0 +1,72 @@
+package nsv
+
+import (
+	"strings"
+
+	git "github.com/purpleclay/gitz"
+)
+
+const revertsCommit = "This reverts commit "
+
+// Revert pairs a revert commit with the commit it reverts, with both
+// identified by their index within the log
+type Revert struct {
+	Index    int
+	Reverted int
+}
+
+// DetectReverts scans the log for any commits generated by git revert, identified
+// by the trailing "This reverts commit <hash>" within their body. Only reverts of
+// commits within the log are detected, allowing both to be cancelled out. Reverting
+// a revert will restore the original commit
+func DetectReverts(log []git.LogEntry) []Revert {
+	var reverts []Revert
+	cancelled := map[int]struct{}{}
+
+	// The log is ordered from newest to oldest, ensuring the latest revert always wins
+	for i, entry := range log {
+		if _, found := cancelled[i]; found {
+			continue
+		}
+
+		hash := revertedHash(entry.Message)
+		if hash == "" {
+			continue
+		}
+
+		for j := i + 1; j < len(log); j++ {
+			if _, found := cancelled[j]; found || !strings.HasPrefix(log[j].Hash, hash) {
+				continue
+			}
+
+			reverts = append(reverts, Revert{Index: i, Reverted: j})
+			cancelled[i] = struct{}{}
+			cancelled[j] = struct{}{}
+			break
+		}
+	}
+
+	return reverts
+}
+
+func revertedHash(msg string) string {
+	idx := strings.Index(msg, revertsCommit)
+	if idx == -1 {
+		return ""
+	}
+
+	hash := msg[idx+len(revertsCommit):]
+	end := strings.IndexFunc(hash, func(r rune) bool {
+		return !strings.ContainsRune("0123456789abcdef", r)
+	})
+	if end > -1 {
+		hash = hash[:end]
+	}
+
+	// Protect against matching against a partial hash that is too short to be unique
+	if len(hash) < 7 {
+		return ""
+	}
+
+	return hash
+}
@@ -0,0 +1,75 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectReverts(t *testing.T) {
+	t.Parallel()
+
+	log := []git.LogEntry{
+		{
+			Hash: "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+			Message: `Revert "feat!: switch search to use graphql"
+
+This reverts commit 9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b.`,
+		},
+		{
+			Hash:    "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+			Message: "fix: search results are not sorted by relevance",
+		},
+		{
+			Hash:    "9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+			Message: "feat!: switch search to use graphql",
+		},
+	}
+
+	reverts := nsv.DetectReverts(log)
+	assert.Equal(t, []nsv.Revert{{Index: 0, Reverted: 2}}, reverts)
+}
+
+func TestDetectRevertsRevertOfRevert(t *testing.T) {
+	t.Parallel()
+
+	log := []git.LogEntry{
+		{
+			Hash: "5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d",
+			Message: `Revert "Revert "feat: support searching by tags""
+
+This reverts commit 3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d.`,
+		},
+		{
+			Hash: "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+			Message: `Revert "feat: support searching by tags"
+
+This reverts commit 9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b.`,
+		},
+		{
+			Hash:    "9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+			Message: "feat: support searching by tags",
+		},
+	}
+
+	reverts := nsv.DetectReverts(log)
+	assert.Equal(t, []nsv.Revert{{Index: 0, Reverted: 1}}, reverts)
+}
+
+func TestDetectRevertsOutsideOfLog(t *testing.T) {
+	t.Parallel()
+
+	log := []git.LogEntry{
+		{
+			Hash: "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+			Message: `Revert "feat: support searching by tags"
+
+This reverts commit 9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b.`,
+		},
+	}
+
+	reverts := nsv.DetectReverts(log)
+	assert.Empty(t, reverts)
+}
@@ -0,0 +1,212 @@
+package nsv
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AnyScope can be used within a rule to only match a conventional commit
+// type when a scope is present, e.g. perf(*)=patch
+const AnyScope = "*"
+
+var typeScopeRgx = regexp.MustCompile(`^([\w-]+)(?:\(([^()]*)\))?$`)
+
+type InvalidRuleError struct {
+	Rule   string
+	Reason string
+}
+
+func (e InvalidRuleError) Error() string {
+	return fmt.Sprintf("rule '%s' is invalid, %s", e.Rule, e.Reason)
+}
+
+// Rule maps a conventional commit type, and an optional scope, to a semantic
+// version increment. A rule with an empty scope will match any commit of that
+// type, regardless of its scope
+type Rule struct {
+	Type      string
+	Scope     string
+	Increment Increment
+}
+
+func (r Rule) String() string {
+	if r.Scope == "" {
+		return r.Type
+	}
+	return fmt.Sprintf("%s(%s)", r.Type, r.Scope)
+}
+
+// weight determines how specific a rule is. An exact scope outweighs
+// any scope, which in turn outweighs a rule with no scope
+func (r Rule) weight() int {
+	switch r.Scope {
+	case "":
+		return 0
+	case AnyScope:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (r Rule) matches(typ, scope string) bool {
+	if r.Type != typ {
+		return false
+	}
+
+	switch r.Scope {
+	case "":
+		return true
+	case AnyScope:
+		return scope != ""
+	default:
+		return r.Scope == scope
+	}
+}
+
+// Rules is a table of rules used for detecting an increment from the type
+// and scope of a conventional commit
+type Rules []Rule
+
+// Lookup finds the increment of the most specific rule matching the given
+// type and scope. If multiple rules are equally specific, the last defined
+// rule wins. Both type and scope are matched case-insensitively
+func (r Rules) Lookup(typ, scope string) (Increment, bool) {
+	typ = strings.ToUpper(typ)
+	scope = strings.ToUpper(scope)
+
+	matched := -1
+	inc := NoIncrement
+	for _, rule := range r {
+		if !rule.matches(typ, scope) {
+			continue
+		}
+
+		if w := rule.weight(); w >= matched {
+			matched = w
+			inc = rule.Increment
+		}
+	}
+
+	return inc, matched != -1
+}
+
+// Effective returns the rules that take effect during a [Rules.Lookup], one for each
+// type and scope, ordered by type and then scope. If a type and scope is defined
+// multiple times, the last defined rule wins
+func (r Rules) Effective() Rules {
+	last := map[Rule]int{}
+	for i, rule := range r {
+		last[Rule{Type: rule.Type, Scope: rule.Scope}] = i
+	}
+
+	sorted := make(Rules, 0, len(last))
+	for i, rule := range r {
+		if last[Rule{Type: rule.Type, Scope: rule.Scope}] == i {
+			sorted = append(sorted, rule)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].weight() < sorted[j].weight()
+	})
+	return sorted
+}
+
+// ParseRule parses a rule in the format type[(scope)]=increment, where the
+// increment can be one of either major, minor, patch or none
+func ParseRule(rule string) (Rule, error) {
+	prefix, increment, found := strings.Cut(rule, "=")
+	if !found {
+		return Rule{}, InvalidRuleError{Rule: rule, Reason: "expected the format type[(scope)]=increment"}
+	}
+
+	parsed, err := parsePrefix(prefix)
+	if err != nil {
+		return Rule{}, InvalidRuleError{Rule: rule, Reason: err.Error()}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(increment)) {
+	case "major":
+		parsed.Increment = MajorIncrement
+	case "minor":
+		parsed.Increment = MinorIncrement
+	case "patch":
+		parsed.Increment = PatchIncrement
+	case "none":
+		parsed.Increment = NoIncrement
+	default:
+		return Rule{}, InvalidRuleError{Rule: rule, Reason: "increment must be one of either major, minor, patch or none"}
+	}
+
+	return parsed, nil
+}
+
+// ParseRules parses each rule in turn, see [ParseRule]
+func ParseRules(rules []string) (Rules, error) {
+	parsed := make(Rules, 0, len(rules))
+	for _, rule := range rules {
+		r, err := ParseRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, r)
+	}
+
+	return parsed, nil
+}
+
+func parsePrefix(prefix string) (Rule, error) {
+	typ, scope, ok := splitType(strings.TrimSpace(prefix))
+	if !ok {
+		return Rule{}, fmt.Errorf("prefix '%s' is not a valid conventional commit type", prefix)
+	}
+
+	return Rule{Type: typ, Scope: scope}, nil
+}
+
+// splitType splits the leading type of a conventional commit into its
+// uppercase type and scope, e.g. feat(ui) becomes FEAT and UI
+func splitType(leadingType string) (string, string, bool) {
+	m := typeScopeRgx.FindStringSubmatch(leadingType)
+	if m == nil {
+		return "", "", false
+	}
+
+	return strings.ToUpper(m[1]), strings.ToUpper(m[2]), true
+}
+
+// splitCustomType splits a leading type that is not a valid conventional commit type,
+// such as chore.deps, into its uppercase type and any trailing scope
+func splitCustomType(leadingType string) (string, string) {
+	leadingType = strings.ToUpper(strings.TrimSpace(leadingType))
+	if idx := strings.Index(leadingType, "("); idx > 0 && strings.HasSuffix(leadingType, ")") {
+		return leadingType[:idx], leadingType[idx+1 : len(leadingType)-1]
+	}
+
+	return leadingType, ""
+}
+
+// prefixRules maps each prefix to a rule. A prefix that is not a valid conventional
+// commit type is still matched against the leading type of each commit as is
+func prefixRules(prefixes []string, inc Increment) Rules {
+	rules := make(Rules, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		rule, err := parsePrefix(prefix)
+		if err != nil {
+			if rule.Type, rule.Scope = splitCustomType(prefix); rule.Type == "" {
+				continue
+			}
+		}
+
+		rule.Increment = inc
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
@@ -0,0 +1,149 @@
+package nsv_test
+
+import (
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rule     string
+		expected nsv.Rule
+	}{
+		{
+			name:     "TypeOnly",
+			rule:     "feat=minor",
+			expected: nsv.Rule{Type: "FEAT", Increment: nsv.MinorIncrement},
+		},
+		{
+			name:     "WithScope",
+			rule:     "feat(internal)=patch",
+			expected: nsv.Rule{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.PatchIncrement},
+		},
+		{
+			name:     "WithAnyScope",
+			rule:     "perf(*)=patch",
+			expected: nsv.Rule{Type: "PERF", Scope: nsv.AnyScope, Increment: nsv.PatchIncrement},
+		},
+		{
+			name:     "None",
+			rule:     "docs = NONE",
+			expected: nsv.Rule{Type: "DOCS", Increment: nsv.NoIncrement},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := nsv.ParseRule(tt.rule)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule)
+		})
+	}
+}
+
+func TestParseRuleInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule string
+		err  string
+	}{
+		{
+			name: "MissingIncrement",
+			rule: "feat",
+			err:  "rule 'feat' is invalid, expected the format type[(scope)]=increment",
+		},
+		{
+			name: "UnsupportedIncrement",
+			rule: "feat=huge",
+			err:  "rule 'feat=huge' is invalid, increment must be one of either major, minor, patch or none",
+		},
+		{
+			name: "InvalidType",
+			rule: "feat(ui=minor",
+			err:  "rule 'feat(ui=minor' is invalid, prefix 'feat(ui' is not a valid conventional commit type",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := nsv.ParseRule(tt.rule)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestRulesLookupMostSpecificWins(t *testing.T) {
+	rules := nsv.Rules{
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Scope: nsv.AnyScope, Increment: nsv.MajorIncrement},
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+	}
+
+	inc, found := rules.Lookup("feat", "internal")
+	require.True(t, found)
+	assert.Equal(t, nsv.PatchIncrement, inc)
+
+	inc, _ = rules.Lookup("feat", "ui")
+	assert.Equal(t, nsv.MajorIncrement, inc)
+
+	inc, _ = rules.Lookup("feat", "")
+	assert.Equal(t, nsv.MinorIncrement, inc)
+
+	_, found = rules.Lookup("fix", "")
+	assert.False(t, found)
+}
+
+func TestRulesLookupLastDefinedWins(t *testing.T) {
+	rules := nsv.Rules{
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+		{Type: "FEAT", Increment: nsv.NoIncrement},
+	}
+
+	inc, found := rules.Lookup("feat", "")
+	require.True(t, found)
+	assert.Equal(t, nsv.NoIncrement, inc)
+}
+
+func TestAngularRules(t *testing.T) {
+	rules, err := nsv.AngularRules(nsv.Options{
+		PatchPrefixes: []string{"fix", "chore(deps)"},
+		Rules:         []string{"feat(internal)=patch"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, nsv.Rules{
+		{Type: "FIX", Increment: nsv.PatchIncrement},
+		{Type: "CHORE", Scope: "DEPS", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.PatchIncrement},
+	}, rules)
+}
+
+func TestRulesEffective(t *testing.T) {
+	rules := nsv.Rules{
+		{Type: "FIX", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+		{Type: "FIX", Increment: nsv.MinorIncrement},
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.NoIncrement},
+	}
+
+	assert.Equal(t, nsv.Rules{
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.NoIncrement},
+		{Type: "FIX", Increment: nsv.MinorIncrement},
+	}, rules.Effective())
+}
@@ -3,21 +3,19 @@ package nsv
 import (
 	"bytes"
 	"fmt"
-	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/charmbracelet/log"
 	git "github.com/purpleclay/gitz"
 )
 
 const (
-	vPrefix          = 'v'
-	firstVer         = "0.0.0"
-	prefixedFirstVer = "v0.0.0"
+	vPrefix  = 'v'
+	firstVer = "0.0.0"
 )
 
 type Increment int
@@ -43,34 +41,55 @@ func (i Increment) String() string {
 }
 
 type Options struct {
-	FixShallow    bool
-	Hook          string
-	Logger        *log.Logger
-	MajorPrefixes []string
-	MinorPrefixes []string
-	PatchPrefixes []string
-	Path          string
-	VersionFormat string
+	BaseRef          string
+	Convention       string
+	FixShallow       bool
+	GoModule         string
+	Hook             string
+	IgnoreAuthors    []string
+	IgnoreCommits    []string
+	InitialVersion   string
+	Logger           Logger
+	MajorPattern     string
+	MajorPrefixes    []string
+	MinorPattern     string
+	MinorPrefixes    []string
+	MinVersion       string
+	NoDefaultIgnores bool
+	OnCollision      string
+	ParseBody        bool
+	PatchPattern     string
+	PatchPrefixes    []string
+	Path             string
+	Ref              string
+	RemoteTags       []string
+	Rules            []string
+	Since            string
+	VersionFormat    string
+	VPrefix          bool
 }
 
 type gitContext struct {
 	TagPrefix string
 	LogPath   string
+
+	// Language is the ecosystem detected within the log path, if any
+	Language Language
+
+	// TagFormat is the default format of the detected language, used to both find
+	// and format tags when no format is provided
+	TagFormat string
 }
 
-func resolveContext(gitc *git.Client, opts Options) (*gitContext, error) {
-	cwd, err := os.Getwd()
+func resolveContext(repo Repository, opts Options) (*gitContext, error) {
+	cwd, err := repo.RelativePath()
 	if err != nil {
 		return nil, err
 	}
 
-	var relPath string
+	relPath := cwd
 	if opts.Path != "" {
 		relPath = opts.Path
-	} else {
-		if relPath, err = gitc.ToRelativePath(cwd); err != nil {
-			return nil, err
-		}
 	}
 
 	var tagPrefix string
@@ -81,25 +100,120 @@ func resolveContext(gitc *git.Client, opts Options) (*gitContext, error) {
 		}
 	}
 
-	if relPath == git.RelativeAtRoot {
-		opts.Logger.Debug("resolved git context", "tag_prefix", tagPrefix, "log_path", relPath)
-		return &gitContext{TagPrefix: tagPrefix, LogPath: relPath}, nil
+	logPath := relPath
+	if relPath != git.RelativeAtRoot {
+		if strings.HasSuffix(cwd, logPath) {
+			logPath = git.RelativeAtRoot
+		}
+
+		if tagPrefix == "" {
+			tagPrefix = filepath.Base(relPath)
+		}
 	}
 
-	logPath := relPath
-	if strings.HasSuffix(cwd, logPath) {
-		logPath = git.RelativeAtRoot
+	ctx := &gitContext{TagPrefix: tagPrefix, LogPath: logPath}
+	if lang, manifest, detected := detectLanguage(repo.WorkTree(), logPath); detected {
+		ctx.Language = lang
+		if opts.VersionFormat == "" && lang.TagFormat != nil {
+			if ctx.TagFormat, err = languageTagFormat(repo, ctx, lang, manifest, opts); err != nil {
+				return nil, err
+			}
+
+			// The format of the language names each tag, replacing the prefix
+			if ctx.TagFormat != "" {
+				ctx.TagPrefix = ""
+			}
+		}
+		opts.Logger.Debug("detected language", "language", lang.Name, "manifest", manifest, "tag_format", ctx.TagFormat)
 	}
 
-	if tagPrefix == "" {
-		tagPrefix = filepath.Base(relPath)
+	opts.Logger.Debug("resolved git context", "tag_prefix", ctx.TagPrefix, "log_path", ctx.LogPath)
+	return ctx, nil
+}
+
+// languageTagFormat resolves the default format of a detected language. It is only
+// adopted by paths without a release, so any existing tags that use the default
+// prefix continue to be versioned as before
+func languageTagFormat(repo Repository, ctx *gitContext, lang Language, manifest string, opts Options) (string, error) {
+	tags, err := repo.Tags(opts.Ref)
+	if err != nil {
+		return "", err
 	}
 
-	opts.Logger.Debug("resolved git context", "tag_prefix", tagPrefix, "log_path", logPath)
-	return &gitContext{
-		TagPrefix: tagPrefix,
-		LogPath:   logPath,
-	}, nil
+	tagged := slices.ContainsFunc(tags, func(raw string) bool {
+		tag, err := ParseTag(raw)
+		return err == nil && tag.Prefix == ctx.TagPrefix
+	})
+
+	if tagged {
+		opts.Logger.Debug("ignoring tag format of language as path is already tagged", "language", lang.Name, "tag_prefix", ctx.TagPrefix)
+		return "", nil
+	}
+
+	return lang.TagFormat(repo.WorkTree(), manifest), nil
+}
+
+// withLanguage defaults the format and initial version to the conventions of the
+// detected language, unless already set
+func (c *gitContext) withLanguage(opts Options) Options {
+	if opts.VersionFormat == "" {
+		opts.VersionFormat = c.TagFormat
+	}
+
+	if opts.InitialVersion == "" {
+		opts.InitialVersion = c.Language.InitialVersion
+	}
+	return opts
+}
+
+// parseTag parses a tag, first removing any text added by the default format of
+// the detected language
+func (c *gitContext) parseTag(raw string) (Tag, error) {
+	if c.TagFormat == "" {
+		return ParseTag(raw)
+	}
+
+	before, after := formatAffixes(c.TagFormat)
+	if len(raw) <= len(before)+len(after) || !strings.HasPrefix(raw, before) || !strings.HasSuffix(raw, after) {
+		return Tag{}, fmt.Errorf("tag %s does not match the format %s", raw, c.TagFormat)
+	}
+
+	return ParseTag(raw[len(before) : len(raw)-len(after)])
+}
+
+// latestTag finds the latest semantic version tag within the context. If a label
+// is provided, only prereleases with that label are considered
+func (c *gitContext) latestTag(tags []string, label string) string {
+	if c.TagFormat == "" {
+		return latestTag(tags, c.TagPrefix, label)
+	}
+
+	var latest string
+	var latestVer *semver.Version
+	for _, raw := range tags {
+		tag, err := c.parseTag(raw)
+		if err != nil || (label != "" && !tag.PrereleaseWithLabel(label)) {
+			continue
+		}
+
+		ver, _ := semver.StrictNewVersion(tag.SemVer)
+		if latestVer == nil || ver.GreaterThan(latestVer) {
+			latest = raw
+			latestVer = ver
+		}
+	}
+
+	return latest
+}
+
+// formatAffixes renders a format around a placeholder version, identifying the text
+// either side of the version within a formatted tag
+func formatAffixes(format string) (string, string) {
+	const placeholder = "\x00"
+
+	formatted := Tag{Raw: placeholder, SemVer: placeholder, Version: placeholder}.Format(format)
+	before, after, _ := strings.Cut(formatted, placeholder)
+	return before, after
 }
 
 type Tag struct {
@@ -178,12 +292,17 @@ func (t Tag) PrereleaseWithLabel(label string) bool {
 }
 
 type Next struct {
-	Diffs   []git.FileDiff
-	Log     []git.LogEntry
-	LogDir  string
-	Match   Match
-	PrevTag string
-	Tag     string
+	Diffs     []git.FileDiff
+	Ignored   []Ignored
+	Increment Increment
+	Log       []git.LogEntry
+	LogDir    string
+	Match     Match
+	PrevTag   string
+	Reverts   []Revert
+	Tag       string
+	TagTarget string
+	TagType   string
 }
 
 type Match struct {
@@ -192,73 +311,223 @@ type Match struct {
 	Start int
 }
 
-func NextVersion(gitc *git.Client, opts Options) (*Next, error) {
-	if err := checkAndHealRepository(gitc, opts); err != nil {
+// activeCommits filters any reverted or ignored commits from the log, returning the
+// original index of each remaining commit
+func activeCommits(log []git.LogEntry, reverts []Revert, ignored []Ignored) ([]git.LogEntry, []int) {
+	excluded := map[int]struct{}{}
+	for _, revert := range reverts {
+		excluded[revert.Index] = struct{}{}
+		excluded[revert.Reverted] = struct{}{}
+	}
+
+	for _, ignore := range ignored {
+		excluded[ignore.Index] = struct{}{}
+	}
+
+	filtered := make([]git.LogEntry, 0, len(log))
+	indexes := make([]int, 0, len(log))
+	for i, entry := range log {
+		if _, found := excluded[i]; found {
+			continue
+		}
+
+		filtered = append(filtered, entry)
+		indexes = append(indexes, i)
+	}
+
+	return filtered, indexes
+}
+
+func originalMatch(match Match, indexes []int) Match {
+	if match.Index != noMatchIdx {
+		match.Index = indexes[match.Index]
+	}
+
+	return match
+}
+
+// detectIncrement scans the active commits within a log for the next increment. Commands
+// are detected first as they have a higher precedence over conventional commits. The
+// returned match is relative to the original log
+func detectIncrement(log, active []git.LogEntry, indexes []int, opts Options) (Command, Increment, Match, error) {
+	cmd, match := DetectCommand(active)
+	match = originalMatch(match, indexes)
+	opts.Logger.Debug("scanned git log for nsv commands", "force", cmd.Force.String(), "prerelease", cmd.Prerelease, "set", cmd.Set)
+
+	inc := cmd.Force
+	if inc == NoIncrement && cmd.Set == "" {
+		convention, err := NewConvention(opts)
+		if err != nil {
+			return Command{}, NoIncrement, NoMatch, err
+		}
+
+		inc, match = convention.DetectIncrement(active)
+		match = originalMatch(match, indexes)
+
+		convInfo := []any{"increment", inc.String()}
+		if match.Index != noMatchIdx {
+			convInfo = append(convInfo,
+				"pref",
+				log[match.Index].Message[match.Start:match.End],
+			)
+		}
+		opts.Logger.Debug("scanned git log for commit convention", convInfo...)
+	}
+
+	return cmd, inc, match, nil
+}
+
+// resolveNextTag bumps the previous version by the detected increment, unless the next
+// version is pinned by an nsv command or an initial version. The next version is then
+// raised to any minimum version
+func resolveNextTag(ver Tag, first bool, inc Increment, cmd Command, opts Options) (Tag, Increment, error) {
+	var nextTag Tag
+	var err error
+	switch {
+	case cmd.Set != "":
+		if nextTag, inc, err = setVersion(ver, cmd.Set); err != nil {
+			return Tag{}, NoIncrement, err
+		}
+		opts.Logger.Info("next semantic version set by nsv command", "set", cmd.Set)
+	case first && opts.InitialVersion != "":
+		nextTag = ver.Bump(strings.TrimPrefix(opts.InitialVersion, string(vPrefix)))
+		opts.Logger.Debug("using initial semantic version", "initial", opts.InitialVersion)
+	default:
+		if nextTag, err = bump(ver, inc, cmd); err != nil {
+			return Tag{}, NoIncrement, err
+		}
+	}
+
+	if opts.MinVersion != "" {
+		if nextTag, err = floorVersion(nextTag, opts.MinVersion, cmd); err != nil {
+			return Tag{}, NoIncrement, err
+		}
+	}
+
+	return nextTag, inc, nil
+}
+
+// checkSinceTag ensures a since tag can replace the latest tag. It must be a semantic
+// version, reachable from the ref, that belongs to the path being versioned
+func checkSinceTag(ctx *gitContext, tags []string, since string) error {
+	tag, err := ctx.parseTag(since)
+	if err != nil {
+		return InvalidVersionError{Name: "since tag", Version: since}
+	}
+
+	if !slices.Contains(tags, since) {
+		return SinceTagError{Tag: since, Reason: "it does not exist or is not reachable from the ref"}
+	}
+
+	if ctx.TagFormat == "" && tag.Prefix != ctx.TagPrefix {
+		return SinceTagError{Tag: since, Reason: fmt.Sprintf("it does not have the tag prefix '%s'", ctx.TagPrefix)}
+	}
+
+	return nil
+}
+
+// resolveLatestTag lists all tags reachable from the ref, allowing a past release
+// to be recomputed, and identifies the latest tag. A since tag takes precedence
+func resolveLatestTag(repo Repository, ctx *gitContext, opts Options) ([]string, string, error) {
+	tags, err := repo.Tags(opts.Ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ltag := ctx.latestTag(tags, "")
+	if opts.Since != "" {
+		if err := checkSinceTag(ctx, tags, opts.Since); err != nil {
+			return nil, "", err
+		}
+		ltag = opts.Since
+	}
+	opts.Logger.Info("identified the latest git tag", "tag", ltag, "ref", headIfEmpty(opts.Ref))
+
+	return tags, ltag, nil
+}
+
+func NextVersion(repo Repository, opts Options) (*Next, error) {
+	if err := checkAndHealRepository(repo, opts); err != nil {
 		return nil, err
 	}
 
-	ctx, err := resolveContext(gitc, opts)
+	ctx, err := resolveContext(repo, opts)
 	if err != nil {
 		return nil, err
 	}
+	opts = ctx.withLanguage(opts)
 
-	ltag, err := latestTag(gitc, ctx.TagPrefix)
+	tags, ltag, err := resolveLatestTag(repo, ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	opts.Logger.Info("identified the latest git tag", "tag", ltag)
 
-	log, err := gitc.Log(git.WithPaths(ctx.LogPath), git.WithRefRange(git.HeadRef, ltag))
+	// A base ref narrows the log to a subset of commits, such as those within a pull request
+	logFrom := ltag
+	if opts.BaseRef != "" {
+		logFrom = opts.BaseRef
+	}
+
+	log, err := repo.Log(opts.Ref, logFrom, ctx.LogPath)
 	if err != nil {
 		return nil, err
 	}
-	opts.Logger.Info("retrieved git log", "commits", len(log.Commits), "log_path", ctx.LogPath)
+	opts.Logger.Info("retrieved git log", "commits", len(log), "log_path", ctx.LogPath, "from", logFrom)
 
-	// Detect commands first as they have a higher precedence over conventional commits
-	var inc Increment
-	cmd, match := DetectCommand(log.Commits)
-	opts.Logger.Debug("scanned git log for nsv commands", "force", cmd.Force.String(), "prerelease", cmd.Prerelease)
+	// Cancel out any reverted or ignored commits, preventing them from influencing the next semantic version
+	reverts := DetectReverts(log)
+	if len(reverts) > 0 {
+		opts.Logger.Info("cancelled out reverted commits", "reverts", len(reverts))
+	}
 
-	inc = cmd.Force
-	if inc == NoIncrement {
-		inc, match = AngularMerge(
-			opts.MajorPrefixes,
-			opts.MinorPrefixes,
-			opts.PatchPrefixes,
-		).DetectIncrement(log.Commits)
+	ignored, err := detectIgnored(repo, log, logFrom, ctx.LogPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(ignored) > 0 {
+		opts.Logger.Info("ignored commits", "ignored", len(ignored))
+	}
+	active, indexes := activeCommits(log, reverts, ignored)
 
-		convInfo := []any{"increment", inc.String()}
-		if match.Index != noMatchIdx {
-			convInfo = append(convInfo,
-				"pref",
-				log.Commits[match.Index].Message[:match.End],
-			)
-		}
-		opts.Logger.Debug("scanned git log for conventional prefixes", convInfo...)
+	cmd, inc, match, err := detectIncrement(log, active, indexes, opts)
+	if err != nil {
+		return nil, err
 	}
-	if inc == NoIncrement {
+	if inc == NoIncrement && cmd.Set == "" {
 		opts.Logger.Info("no next semantic version detected", "increment", inc.String())
 		return nil, nil
 	}
 
-	if ltag == "" {
-		ltag = firstVersion(ctx)
+	first := ltag == ""
+	if first {
+		ltag = firstVersion(ctx, opts)
 		opts.Logger.Debug("defaulting to first semantic version", "tag", ltag)
 	}
-	ver, _ := ParseTag(ltag)
+	ver, _ := ctx.parseTag(ltag)
 
 	if cmd.Prerelease != "" && !ver.PrereleaseWithLabel(cmd.Prerelease) {
 		// To prevent any conflict with prerelease tags, query git for the latest tag based
 		// on the prerelease label. Patch existing tag as needed
-		if preTag, _ := latestPrereleaseTag(gitc, ctx.TagPrefix, cmd.Prerelease); preTag != "" {
-			ver, _ = ParseTag(preTag)
+		if preTag := ctx.latestTag(tags, cmd.Prerelease); preTag != "" {
+			ver, _ = ctx.parseTag(preTag)
 		}
 	}
 
-	nextVer, err := bump(ver, opts.VersionFormat, inc, cmd)
+	nextTag, inc, err := resolveNextTag(ver, first, inc, cmd, opts)
 	if err != nil {
 		return nil, err
 	}
+	nextVer := nextTag.Format(opts.VersionFormat)
+
+	if opts.OnCollision != "" {
+		if nextVer, err = resolveCollision(repo, nextVer, opts); err != nil {
+			return nil, err
+		}
+
+		if nextVer == "" {
+			return nil, nil
+		}
+	}
 	opts.Logger.Info("next semantic version",
 		"next",
 		nextVer,
@@ -267,18 +536,47 @@ func NextVersion(gitc *git.Client, opts Options) (*Next, error) {
 		"increment",
 		inc.String(),
 		"hash",
-		log.Commits[match.Index].AbbrevHash,
+		log[match.Index].AbbrevHash,
 	)
 
+	diffs, err := patchRelease(repo, ctx.LogPath, ltag, nextVer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Next{
+		Diffs:     diffs,
+		Ignored:   ignored,
+		Increment: inc,
+		Log:       log,
+		LogDir:    ctx.LogPath,
+		Match:     match,
+		PrevTag:   ltag,
+		Reverts:   reverts,
+		Tag:       nextVer,
+	}, nil
+}
+
+// patchRelease patches any files needed for the next release, either by rewriting a go
+// module path or running a hook, returning the changes made
+func patchRelease(repo Repository, logPath, prevTag, nextVer string, opts Options) ([]git.FileDiff, error) {
 	var diffs []git.FileDiff
+	var patched bool
+	if nextTag, err := ParseTag(nextVer); err == nil {
+		if patched, err = checkGoModule(repo.WorkTree(), logPath, nextTag, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
 	if opts.Hook != "" {
 		if diffs, err = execHook(
-			gitc,
+			repo,
 			opts.Hook,
 			[]string{
-				"NSV_PREV_TAG=" + ltag,
+				"NSV_PREV_TAG=" + prevTag,
 				"NSV_NEXT_TAG=" + nextVer,
-				"NSV_WORKING_DIRECTORY=" + ctx.LogPath,
+				"NSV_WORKING_DIRECTORY=" + logPath,
 			},
 			opts.Logger,
 		); err != nil {
@@ -286,52 +584,52 @@ func NextVersion(gitc *git.Client, opts Options) (*Next, error) {
 		}
 	}
 
-	return &Next{
-		Diffs:   diffs,
-		Log:     log.Commits,
-		LogDir:  ctx.LogPath,
-		Match:   match,
-		PrevTag: ltag,
-		Tag:     nextVer,
-	}, nil
-}
+	if patched && opts.Hook == "" {
+		if diffs, err = repo.Diff(); err != nil {
+			return nil, err
+		}
+	}
 
-func latestTag(gitc *git.Client, prefix string) (string, error) {
-	return latestTagByGlob(gitc, prefix, "**/*.*.*")
+	return diffs, nil
 }
 
-func latestTagByGlob(gitc *git.Client, prefix, glob string) (string, error) {
-	prefixFilter := func(tag string) bool {
-		if prefix == "" {
-			return true
+// latestTag finds the latest semantic version tag with a given prefix. If a label
+// is provided, only prereleases with that label are considered
+func latestTag(tags []string, prefix, label string) string {
+	var latest string
+	var latestVer *semver.Version
+	for _, raw := range tags {
+		if prefix != "" && !strings.HasPrefix(raw, prefix+"/") {
+			continue
 		}
 
-		return strings.HasPrefix(tag, prefix+"/")
-	}
-
-	tags, err := gitc.Tags(git.WithShellGlob(glob),
-		git.WithSortBy(git.VersionDesc),
-		git.WithFilters(prefixFilter),
-		git.WithCount(1))
-	if err != nil {
-		return "", err
-	}
+		tag, err := ParseTag(raw)
+		if err != nil || (label != "" && !tag.PrereleaseWithLabel(label)) {
+			continue
+		}
 
-	if len(tags) == 0 {
-		return "", nil
+		ver, _ := semver.StrictNewVersion(tag.SemVer)
+		if latestVer == nil || ver.GreaterThan(latestVer) {
+			latest = raw
+			latestVer = ver
+		}
 	}
 
-	return tags[0], nil
-}
-
-func latestPrereleaseTag(gitc *git.Client, prefix, label string) (string, error) {
-	return latestTagByGlob(gitc, prefix, fmt.Sprintf("**/*.*.*-%s*", label))
+	return latest
 }
 
-func firstVersion(ctx *gitContext) string {
+// firstVersion resolves the tag that the first semantic version is bumped from, when
+// no previous tag exists. A v prefix is only added if required by the detected language,
+// or if it is the convention of the language and has been opted into
+func firstVersion(ctx *gitContext, opts Options) string {
 	fv := firstVer
-	if detectLanguageIsPrefixed(ctx.LogPath) {
-		fv = prefixedFirstVer
+	if ctx.Language.VPrefixRequired || (ctx.Language.VPrefix && opts.VPrefix) {
+		fv = fmt.Sprintf("%c%s", vPrefix, fv)
+	}
+
+	if ctx.TagFormat != "" {
+		tag, _ := ParseTag(fv)
+		return tag.Format(ctx.TagFormat)
 	}
 
 	if ctx.TagPrefix == "" {
@@ -341,10 +639,10 @@ func firstVersion(ctx *gitContext) string {
 	return fmt.Sprintf("%s/%s", ctx.TagPrefix, fv)
 }
 
-func bump(ver Tag, format string, inc Increment, cmd Command) (string, error) {
+func bump(ver Tag, inc Increment, cmd Command) (Tag, error) {
 	semv, err := semver.StrictNewVersion(ver.SemVer)
 	if err != nil {
-		return "", err
+		return Tag{}, err
 	}
 
 	var bumpedVer semver.Version
@@ -383,17 +681,16 @@ func bump(ver Tag, format string, inc Increment, cmd Command) (string, error) {
 		}
 	}
 
-	nextTag := ver.Bump(bumpedVer.String())
-	return nextTag.Format(format), nil
+	return ver.Bump(bumpedVer.String()), nil
 }
 
-func execHook(gitc *git.Client, hook string, env []string, logger *log.Logger) ([]git.FileDiff, error) {
+func execHook(repo Repository, hook string, env []string, logger Logger) ([]git.FileDiff, error) {
 	logger.Info("executing custom hook", "cmd", hook, "env", env)
 	if err := exec(hook, env); err != nil {
 		return nil, err
 	}
 
-	diffs, err := gitc.Diff()
+	diffs, err := repo.Diff()
 	if err != nil {
 		return nil, err
 	}
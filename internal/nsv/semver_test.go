@@ -22,7 +22,7 @@ ci: add parallel testing support to workflow`
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 
@@ -59,7 +59,7 @@ func TestNextVersionFirstVersion(t *testing.T) {
 			gittest.InitRepository(t, gittest.WithLog(tt.log))
 			gitc, _ := git.NewClient()
 
-			next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+			next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 
 			require.NoError(t, err)
 			require.NotNil(t, next)
@@ -74,7 +74,7 @@ func TestNextVersionMajorZeroSemV(t *testing.T) {
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 
 	require.NoError(t, err)
 	require.NotNil(t, next)
@@ -88,7 +88,7 @@ nsv:force~major
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 
 	require.NoError(t, err)
 	require.NotNil(t, next)
@@ -106,7 +106,7 @@ func TestNextVersionIncludesSubDirectoryAsPrefix(t *testing.T) {
 	os.Chdir("src/search")
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 
 	require.NoError(t, err)
 	require.NotNil(t, next)
@@ -122,7 +122,7 @@ feat(search): add ability to search across processed data`
 
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Path: "src/processor", Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Path: "src/processor", Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 
@@ -157,7 +157,7 @@ ci: configure workflow to run benchmarks
 			gittest.InitRepository(t, gittest.WithLog(tt.log))
 			gitc, _ := git.NewClient()
 
-			next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+			next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 
 			require.NoError(t, err)
 			require.NotNil(t, next)
@@ -172,7 +172,7 @@ func TestNextVersionFromPrerelease(t *testing.T) {
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 	assert.Equal(t, "0.2.0", next.Tag)
@@ -185,7 +185,7 @@ nsv:pre~alpha
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 	assert.Equal(t, "0.3.0-alpha.1", next.Tag)
@@ -199,7 +199,7 @@ nsv:pre`
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 	assert.Equal(t, "0.2.0-beta.2", next.Tag)
@@ -215,7 +215,7 @@ nsv:pre~beta`
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
 	require.NoError(t, err)
 	require.NotNil(t, next)
 	assert.Equal(t, "0.1.0-beta.2", next.Tag)
@@ -228,7 +228,7 @@ func TestNextVersionWithFormat(t *testing.T) {
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{VersionFormat: format, Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{VersionFormat: format, Logger: noopLogger})
 
 	require.NoError(t, err)
 	require.NotNil(t, next)
@@ -245,7 +245,7 @@ feat: add a ui for easy searching and management of data
 	gittest.InitRepository(t, gittest.WithLog(log))
 	gitc, _ := git.NewClient()
 
-	next, err := nsv.NextVersion(gitc, nsv.Options{VersionFormat: format, Logger: noopLogger})
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{VersionFormat: format, Logger: noopLogger})
 
 	require.NoError(t, err)
 	require.NotNil(t, next)
@@ -265,7 +265,7 @@ func TestNextVersionWithHook(t *testing.T) {
 	execFile(t, "patch-version.sh", `#!/bin/bash
 echo -n $NSV_NEXT_TAG > VERSION`)
 
-	_, err := nsv.NextVersion(gitc, nsv.Options{Hook: "./patch-version.sh", Logger: noopLogger})
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Hook: "./patch-version.sh", Logger: noopLogger})
 
 	require.NoError(t, err)
 	assert.Equal(t, "0.2.0", readFile(t, "VERSION"))
@@ -294,3 +294,217 @@ func TestParseTag(t *testing.T) {
 	assert.Equal(t, tag.Pre, "beta.1")
 	assert.Equal(t, tag.Metadata, "20230207")
 }
+
+func TestNextVersionCancelsOutRevertedCommits(t *testing.T) {
+	log := `(main, origin/main) fix: search results are not sorted by relevance
+(tag: 1.0.0) feat: support searching by tags`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gittest.TempFile(t, "search.go", "package search")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "feat!: switch search to use graphql")
+	gittest.MustExec(t, "git revert --no-edit HEAD")
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "1.0.1", next.Tag)
+	assert.Equal(t, []nsv.Revert{{Index: 0, Reverted: 1}}, next.Reverts)
+	assert.Equal(t, 2, next.Match.Index)
+}
+
+func TestNextVersionGitmojiConvention(t *testing.T) {
+	log := `(main, origin/main) :memo: document new search improvements
+:bug: search is not being aggregated correctly
+(tag: 0.1.0) :sparkles: support aggregations for search analytics`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{
+		Convention: nsv.GitmojiConvention,
+		Logger:     noopLogger,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "0.1.1", next.Tag)
+	assert.Equal(t, 1, next.Match.Index)
+}
+
+func TestNextVersionIgnoresFixupAndBotCommits(t *testing.T) {
+	log := `(tag: 0.1.0, main, origin/main) feat: support pagination of search results`
+	gittest.InitRepository(t, gittest.WithLog(log))
+
+	gittest.TempFile(t, "go.mod", "module search")
+	gittest.StageFile(t, "go.mod")
+	gittest.MustExec(t, `git commit --author="renovate[bot] <29139614+renovate[bot]@users.noreply.github.com>" -m "fix(deps): update module github.com/charmbracelet/log to v0.4.2"`)
+
+	gittest.TempFile(t, "search.go", "package search")
+	gittest.StageFile(t, "search.go")
+	gittest.Commit(t, "fixup! feat: support pagination of search results")
+
+	gitc, _ := git.NewClient()
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+	next, err = nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, NoDefaultIgnores: true})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.1.1", next.Tag)
+}
+
+func TestNextVersionInitialVersion(t *testing.T) {
+	log := "(main) feat: support searching by tags"
+	gittest.InitRepository(t,
+		gittest.WithLog(log),
+		gittest.WithCommittedFiles("go.mod"))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{InitialVersion: "1.0.0", Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "v1.0.0", next.Tag)
+}
+
+func TestNextVersionInitialVersionIgnoredWithTag(t *testing.T) {
+	log := `> (main, origin/main) feat: support searching by tags
+> (tag: 0.2.0) feat: support searching by labels`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{InitialVersion: "1.0.0", Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "0.3.0", next.Tag)
+}
+
+func TestNextVersionMinVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      string
+		expected string
+	}{
+		{
+			name: "RaisedToFloor",
+			log: `> (main, origin/main) fix: stability issues around long running database connectivity
+> (tag: 0.9.9) feat: support database connection pooling`,
+			expected: "1.0.0",
+		},
+		{
+			name: "PrereleaseRaisedToFloor",
+			log: `> (main, origin/main) feat: experimental streaming of query results
+nsv:pre~rc
+> (tag: 0.9.9) feat: support database connection pooling`,
+			expected: "1.0.0-rc.1",
+		},
+		{
+			name: "AboveFloor",
+			log: `> (main, origin/main) fix: stability issues around long running database connectivity
+> (tag: 1.2.0) feat: support database connection pooling`,
+			expected: "1.2.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gittest.InitRepository(t, gittest.WithLog(tt.log))
+			gitc, _ := git.NewClient()
+
+			next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{MinVersion: "1.0.0", Logger: noopLogger})
+			require.NoError(t, err)
+			require.NotNil(t, next)
+			assert.Equal(t, tt.expected, next.Tag)
+		})
+	}
+}
+
+func TestNextVersionSetCommand(t *testing.T) {
+	log := `> (main, origin/main) docs: document the migration away from the legacy api
+nsv:set~2.0.0
+> (tag: 0.4.1) fix: stability issues around long running database connectivity`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "2.0.0", next.Tag)
+	assert.Equal(t, nsv.MajorIncrement, next.Increment)
+	assert.Equal(t, 0, next.Match.Index)
+}
+
+func TestNextVersionSetCommandMustBeGreater(t *testing.T) {
+	log := `> (main, origin/main) docs: document the migration away from the legacy api
+nsv:set~0.4.0
+> (tag: 0.4.1) fix: stability issues around long running database connectivity`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger})
+	require.EqualError(t, err, "version '0.4.0' set by nsv command must be greater than the latest tag 0.4.1")
+}
+
+func TestNextVersionAtRef(t *testing.T) {
+	log := `> (tag: 0.2.0, main, origin/main) feat: support searching by tags
+> fix: search results are not sorted by relevance
+> (tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, Ref: "HEAD~1"})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "0.1.1", next.Tag)
+	assert.Equal(t, "0.1.0", next.PrevTag)
+	require.Len(t, next.Log, 1)
+	assert.Equal(t, "fix: search results are not sorted by relevance", next.Log[0].Message)
+}
+
+func TestNextVersionSince(t *testing.T) {
+	log := `> (main, origin/main) fix: search results are truncated
+> (tag: 0.2.0) feat: support searching by tags
+> (tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, Ref: "0.2.0", Since: "0.1.0"})
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "0.2.0", next.Tag)
+	assert.Equal(t, "0.1.0", next.PrevTag)
+}
+
+func TestNextVersionInvalidSince(t *testing.T) {
+	gittest.InitRepository(t, gittest.WithLog("(main, origin/main) feat: initial search support"))
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, Since: "latest"})
+	require.EqualError(t, err, "since tag 'latest' is not a valid semantic version")
+}
+
+func TestNextVersionSinceUnreachable(t *testing.T) {
+	log := `> (main, origin/main) fix: search results are truncated
+> (tag: 0.2.0) feat: support searching by tags
+> (tag: 0.1.0) feat: initial search support`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.Options{Logger: noopLogger, Ref: "0.1.0", Since: "0.2.0"})
+	require.EqualError(t, err, "since tag '0.2.0' cannot be used, it does not exist or is not reachable from the ref")
+}
+
+func TestNextVersionSinceDifferentPrefix(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat(ui): initial search ui", "src/ui/index.ts")
+	require.NoError(t, repo.Tag("ui/0.1.0", "", ""))
+	repo.Commit("feat(search): initial search api", "src/search/search.go")
+	require.NoError(t, repo.Tag("search/0.1.0", "", ""))
+	repo.Commit("fix(ui): dark mode toggle not persisted", "src/ui/theme.ts")
+
+	_, err := nsv.NextVersion(repo, nsv.Options{Logger: noopLogger, Path: "src/ui", Since: "search/0.1.0"})
+	require.EqualError(t, err, "since tag 'search/0.1.0' cannot be used, it does not have the tag prefix 'ui'")
+}
@@ -0,0 +1,91 @@
+package nsv
+
+import git "github.com/purpleclay/gitz"
+
+// Scenario describes a hypothetical release, used to simulate how the next semantic
+// version would be calculated
+type Scenario struct {
+	// Tag is the latest release. If empty, the commits lead up to the first release
+	Tag string
+
+	// Commits contains the message of each commit made since the latest release,
+	// ordered from oldest to newest
+	Commits []string
+}
+
+// Simulation describes the outcome of simulating a scenario
+type Simulation struct {
+	// Next is the next semantic version. It will be nil if no increment was detected
+	Next *Next
+
+	// Version is the next semantic version before any format is applied
+	Version Tag
+}
+
+// Simulate calculates the next semantic version of a scenario entirely in memory. No
+// repository is needed and nothing on disk is read or patched, so any language based
+// defaults for the first version are not detected
+func Simulate(scenario Scenario, opts Options) (*Simulation, error) {
+	repo := NewMemoryRepository()
+
+	ltag := scenario.Tag
+	if ltag != "" {
+		if _, err := ParseTag(ltag); err != nil {
+			return nil, InvalidVersionError{Name: "starting tag", Version: ltag}
+		}
+
+		repo.Commit("chore: initial commit")
+		repo.Tag(ltag, "", "")
+	}
+
+	for _, msg := range scenario.Commits {
+		repo.Commit(msg)
+	}
+
+	log, err := repo.Log("", ltag, git.RelativeAtRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	reverts := DetectReverts(log)
+	ignored, err := detectIgnored(repo, log, ltag, git.RelativeAtRoot, opts)
+	if err != nil {
+		return nil, err
+	}
+	active, indexes := activeCommits(log, reverts, ignored)
+
+	cmd, inc, match, err := detectIncrement(log, active, indexes, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if inc == NoIncrement && cmd.Set == "" {
+		opts.Logger.Info("no next semantic version detected", "commits", len(log))
+		return &Simulation{}, nil
+	}
+
+	first := ltag == ""
+	if first {
+		ltag = firstVer
+	}
+	ver, _ := ParseTag(ltag)
+
+	nextTag, inc, err := resolveNextTag(ver, first, inc, cmd, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulation{
+		Next: &Next{
+			Ignored:   ignored,
+			Increment: inc,
+			Log:       log,
+			LogDir:    git.RelativeAtRoot,
+			Match:     match,
+			PrevTag:   ltag,
+			Reverts:   reverts,
+			Tag:       nextTag.Format(opts.VersionFormat),
+		},
+		Version: nextTag,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package nsv_test
+
+import (
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name      string
+		scenario  nsv.Scenario
+		opts      nsv.Options
+		expected  string
+		version   string
+		increment nsv.Increment
+	}{
+		{
+			name: "Minor",
+			scenario: nsv.Scenario{
+				Tag:     "v0.1.0",
+				Commits: []string{"fix: search results not sorted", "feat: support pagination of search results"},
+			},
+			expected:  "v0.2.0",
+			version:   "v0.2.0",
+			increment: nsv.MinorIncrement,
+		},
+		{
+			name:      "FirstRelease",
+			scenario:  nsv.Scenario{Commits: []string{"feat: initial search support"}},
+			expected:  "0.1.0",
+			version:   "0.1.0",
+			increment: nsv.MinorIncrement,
+		},
+		{
+			name: "WithRules",
+			scenario: nsv.Scenario{
+				Tag:     "1.0.0",
+				Commits: []string{"feat(internal): cache search indexes"},
+			},
+			opts:      nsv.Options{Rules: []string{"feat(internal)=patch"}},
+			expected:  "1.0.1",
+			version:   "1.0.1",
+			increment: nsv.PatchIncrement,
+		},
+		{
+			name: "WithFormat",
+			scenario: nsv.Scenario{
+				Tag:     "search/1.0.0",
+				Commits: []string{"feat!: drop support for the v1 search api"},
+			},
+			opts:      nsv.Options{VersionFormat: "search/v{{.Version}}"},
+			expected:  "search/v2.0.0",
+			version:   "search/2.0.0",
+			increment: nsv.MajorIncrement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Logger = noopLogger
+
+			sim, err := nsv.Simulate(tt.scenario, tt.opts)
+			require.NoError(t, err)
+			require.NotNil(t, sim.Next)
+
+			assert.Equal(t, tt.expected, sim.Next.Tag)
+			assert.Equal(t, tt.version, sim.Version.Raw)
+			assert.Equal(t, tt.increment, sim.Next.Increment)
+		})
+	}
+}
+
+func TestSimulateNoIncrement(t *testing.T) {
+	sim, err := nsv.Simulate(nsv.Scenario{
+		Tag:     "0.1.0",
+		Commits: []string{"docs: document search options", "fixup! docs: document search options"},
+	}, nsv.Options{Logger: noopLogger})
+	require.NoError(t, err)
+
+	assert.Nil(t, sim.Next)
+}
+
+func TestSimulateInvalidTag(t *testing.T) {
+	_, err := nsv.Simulate(nsv.Scenario{Tag: "latest"}, nsv.Options{Logger: noopLogger})
+	require.EqualError(t, err, "starting tag 'latest' is not a valid semantic version")
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	theme "github.com/purpleclay/lipgloss-theme"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+type BackfillOptions struct {
+	Out io.Writer
+}
+
+// PrintBackfill prints a table of backfilled tags, in the order they would have
+// been released, alongside the commit each tag points to and the first line of
+// the commit that triggered the release
+func PrintBackfill(vers []*nsv.Next, opts BackfillOptions) {
+	rows := [][]string{
+		{
+			theme.U.Render("Tag"),
+			theme.U.Render("Previous"),
+			theme.U.Render("Increment"),
+			theme.U.Render("Commit"),
+			theme.U.Render("Triggered By"),
+		},
+	}
+
+	for _, ver := range vers {
+		var trigger string
+		if ver.Match.Index >= 0 {
+			trigger, _, _ = strings.Cut(ver.Log[ver.Match.Index].Message, "\n")
+		}
+
+		rows = append(rows, []string{
+			ver.Tag,
+			faint.Render(ver.PrevTag),
+			ver.Increment.String(),
+			ver.Log[0].AbbrevHash,
+			trigger,
+		})
+	}
+
+	out := theme.NewTable(rows).
+		Border(theme.ThinBorder).
+		String()
+
+	fmt.Fprint(opts.Out, lipgloss.JoinVertical(
+		lipgloss.Top,
+		"",
+		out,
+	))
+}
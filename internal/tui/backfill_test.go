@@ -0,0 +1,42 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintBackfill(t *testing.T) {
+	t.Parallel()
+
+	vers := []*nsv.Next{
+		{
+			Tag:       "0.1.0",
+			PrevTag:   "0.0.0",
+			Increment: nsv.MinorIncrement,
+			Log: []git.LogEntry{
+				{AbbrevHash: "a1b2c3d", Message: "feat: initial search support"},
+			},
+			Match: nsv.Match{Index: 0, Start: 0, End: 4},
+		},
+		{
+			Tag:       "0.1.1",
+			PrevTag:   "0.1.0",
+			Increment: nsv.PatchIncrement,
+			Log: []git.LogEntry{
+				{AbbrevHash: "e4f5a6b", Message: "docs: document search"},
+				{AbbrevHash: "c7d8e9f", Message: "fix: search results not sorted\n\nresults are now sorted by relevance"},
+			},
+			Match: nsv.Match{Index: 1, Start: 0, End: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintBackfill(vers, tui.BackfillOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintBackfill.golden")
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/list"
+	git "github.com/purpleclay/gitz"
+	theme "github.com/purpleclay/lipgloss-theme"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+type CompareOptions struct {
+	Out io.Writer
+}
+
+// PrintComparison prints all changes between two refs, with commits grouped by
+// their conventional commit type. Any breaking changes are listed first
+func PrintComparison(cmp *nsv.Comparison, opts CompareOptions) {
+	from := cmp.From
+	if from == "" {
+		from = noScope
+	}
+
+	sections := []string{
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			theme.H1.Render(cmp.To),
+			diffMark.Render(),
+			" ",
+			theme.H4.Render(from),
+			" ",
+			faint.Render(fmt.Sprintf("(%s)", cmp.Increment.String())),
+		),
+	}
+
+	if len(cmp.Breaking) > 0 {
+		sections = append(sections, compareSection("Breaking Changes", commitLines(cmp.Breaking)))
+	}
+
+	for _, group := range cmp.Groups {
+		sections = append(sections, compareSection(group.Type, commitLines(group.Commits)))
+	}
+
+	if len(cmp.Files) > 0 {
+		sections = append(sections, compareSection("Files", cmp.Files))
+	}
+
+	fmt.Fprint(opts.Out, lipgloss.JoinVertical(
+		lipgloss.Top,
+		"",
+		strings.Join(sections, "\n\n"),
+	))
+}
+
+func compareSection(title string, items []string) string {
+	return lipgloss.JoinVertical(lipgloss.Top,
+		theme.U.Render(title),
+		padTop.Render(list.New(items).
+			Enumerator(list.Dash).
+			EnumeratorStyle(listEnumerator).
+			String()),
+	)
+}
+
+func commitLines(log []git.LogEntry) []string {
+	lines := make([]string, 0, len(log))
+	for _, entry := range log {
+		msg, _, _ := strings.Cut(entry.Message, "\n")
+		lines = append(lines, theme.Mark.Render(entry.AbbrevHash)+" "+msg)
+	}
+	return lines
+}
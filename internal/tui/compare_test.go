@@ -0,0 +1,40 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintComparison(t *testing.T) {
+	t.Parallel()
+
+	breaking := git.LogEntry{AbbrevHash: "b2c3d4e", Message: "feat(api)!: drop support for the v1 search api"}
+	cmp := &nsv.Comparison{
+		From:      "0.1.0",
+		To:        "0.2.0",
+		Increment: nsv.MajorIncrement,
+		Breaking:  []git.LogEntry{breaking},
+		Groups: []nsv.TypeGroup{
+			{Type: "feat", Commits: []git.LogEntry{breaking}},
+			{
+				Type: "fix",
+				Commits: []git.LogEntry{
+					{AbbrevHash: "c3d4e5f", Message: "fix: search results include deleted documents"},
+					{AbbrevHash: "e5f6a7b", Message: "fix: search results not sorted\n\nresults are now sorted by relevance"},
+				},
+			},
+			{Type: nsv.OtherType, Commits: []git.LogEntry{{AbbrevHash: "d4e5f6a", Message: "Update README.md"}}},
+		},
+		Files: []string{"README.md", "api/v1.go", "search.go"},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintComparison(cmp, tui.CompareOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintComparison.golden")
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	theme "github.com/purpleclay/lipgloss-theme"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+const releaseDateFormat = "2006-01-02"
+
+type HistoryOptions struct {
+	Out io.Writer
+}
+
+// PrintHistory prints a table of releases, in semantic version order, showing
+// when and by whom each release was tagged
+func PrintHistory(releases []nsv.Release, opts HistoryOptions) {
+	rows := [][]string{
+		{
+			theme.U.Render("Tag"),
+			theme.U.Render("Date"),
+			theme.U.Render("Commits"),
+			theme.U.Render("Increment"),
+			theme.U.Render("Tagger"),
+			theme.U.Render("Message"),
+		},
+	}
+
+	for _, rel := range releases {
+		msg, _, _ := strings.Cut(rel.Message, "\n")
+		if msg == "" {
+			msg = faint.Render(noScope)
+		}
+
+		rows = append(rows, []string{
+			rel.Tag,
+			rel.Date.Format(releaseDateFormat),
+			strconv.Itoa(rel.Commits),
+			rel.Increment.String(),
+			rel.Tagger,
+			msg,
+		})
+	}
+
+	out := theme.NewTable(rows).
+		Border(theme.ThinBorder).
+		String()
+
+	fmt.Fprint(opts.Out, lipgloss.JoinVertical(
+		lipgloss.Top,
+		"",
+		out,
+	))
+}
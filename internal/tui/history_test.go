@@ -0,0 +1,39 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintHistory(t *testing.T) {
+	t.Parallel()
+
+	releases := []nsv.Release{
+		{
+			Tag:       "v0.1.0",
+			Date:      time.Date(2024, time.March, 2, 10, 30, 0, 0, time.UTC),
+			Commits:   12,
+			Increment: nsv.MinorIncrement,
+			Tagger:    "batman <batman@dc.com>",
+			Message:   "chore: tagged release v0.1.0",
+		},
+		{
+			Tag:       "v0.1.1",
+			PrevTag:   "v0.1.0",
+			Date:      time.Date(2024, time.March, 9, 16, 0, 0, 0, time.UTC),
+			Commits:   3,
+			Increment: nsv.PatchIncrement,
+			Tagger:    "robin <robin@dc.com>",
+		},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintHistory(releases, tui.HistoryOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintHistory.golden")
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+// HTMLRenderer renders a summary as an HTML fragment, suitable for embedding within
+// a release page. The log of each semantic version is collapsible
+type HTMLRenderer struct {
+	Pretty Pretty
+}
+
+func (r HTMLRenderer) Render(vers []*nsv.Next) string {
+	var buf strings.Builder
+	for _, ver := range vers {
+		r.renderNext(&buf, ver)
+	}
+
+	return buf.String()
+}
+
+func (r HTMLRenderer) renderNext(buf *strings.Builder, next *nsv.Next) {
+	buf.WriteString("<section>\n")
+	fmt.Fprintf(buf, "<h3>%s ↑↑ %s</h3>\n", html.EscapeString(next.Tag), html.EscapeString(next.PrevTag))
+
+	var details []string
+	if next.LogDir != "." {
+		details = append(details, fmt.Sprintf("<li><strong>Path:</strong> <code>%s</code></li>", html.EscapeString(next.LogDir)))
+	}
+
+	if next.TagType != "" {
+		details = append(details, fmt.Sprintf("<li><strong>Tag:</strong> %s → %s</li>",
+			html.EscapeString(next.TagType), html.EscapeString(next.TagTarget)))
+	}
+
+	if len(next.Diffs) > 0 {
+		paths := patchedPaths(next)
+		for i := range paths {
+			paths[i] = "<code>" + html.EscapeString(paths[i]) + "</code>"
+		}
+		details = append(details, "<li><strong>Patches:</strong> "+strings.Join(paths, ", ")+"</li>")
+	}
+
+	if len(details) > 0 {
+		buf.WriteString("<ul>\n" + strings.Join(details, "\n") + "\n</ul>\n")
+	}
+
+	entries := summaryEntries(next, r.Pretty)
+	if r.Pretty == Compact {
+		buf.WriteString("<ul>\n")
+		for _, entry := range entries {
+			buf.WriteString(htmlEntry(entry))
+		}
+		buf.WriteString("</ul>\n")
+		buf.WriteString("</section>\n")
+		return
+	}
+
+	buf.WriteString("<details>\n")
+	fmt.Fprintf(buf, "<summary>%s</summary>\n", commitCount(len(entries)))
+	buf.WriteString("<ul>\n")
+	for _, entry := range entries {
+		buf.WriteString(htmlEntry(entry))
+	}
+	buf.WriteString("</ul>\n")
+	buf.WriteString("</details>\n")
+	buf.WriteString("</section>\n")
+}
+
+func htmlEntry(entry summaryEntry) string {
+	var marker string
+	if entry.Marker != "" {
+		marker = entry.Marker + " "
+	}
+
+	subject := html.EscapeString(entry.Before)
+	if entry.Match != "" {
+		subject += "<mark>" + html.EscapeString(entry.Match) + "</mark>" + html.EscapeString(entry.After)
+	}
+
+	if entry.Footer != "" {
+		subject += " (<mark>" + html.EscapeString(entry.Footer) + "</mark>)"
+	}
+
+	if entry.Note != "" {
+		subject += " <em>" + html.EscapeString(entry.Note) + "</em>"
+	}
+
+	return fmt.Sprintf("<li>%s<code>%s</code> %s</li>\n", marker, entry.Hash, subject)
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+// MarkdownRenderer renders a summary as markdown, suitable for pasting into a pull
+// request or release page. The log of each semantic version is collapsible
+type MarkdownRenderer struct {
+	Pretty Pretty
+}
+
+func (r MarkdownRenderer) Render(vers []*nsv.Next) string {
+	sections := make([]string, 0, len(vers))
+	for _, ver := range vers {
+		sections = append(sections, r.renderNext(ver))
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func (r MarkdownRenderer) renderNext(next *nsv.Next) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "### %s ↑↑ %s\n\n", escapeMarkdown(next.Tag), escapeMarkdown(next.PrevTag))
+
+	var details []string
+	if next.LogDir != "." {
+		details = append(details, "- **Path:** "+markdownCode(next.LogDir))
+	}
+
+	if next.TagType != "" {
+		details = append(details, fmt.Sprintf("- **Tag:** %s → %s", escapeMarkdown(next.TagType), escapeMarkdown(next.TagTarget)))
+	}
+
+	if len(next.Diffs) > 0 {
+		paths := patchedPaths(next)
+		for i := range paths {
+			paths[i] = markdownCode(paths[i])
+		}
+		details = append(details, "- **Patches:** "+strings.Join(paths, ", "))
+	}
+
+	if len(details) > 0 {
+		buf.WriteString(strings.Join(details, "\n") + "\n\n")
+	}
+
+	entries := summaryEntries(next, r.Pretty)
+	if r.Pretty == Compact {
+		for _, entry := range entries {
+			buf.WriteString(markdownEntry(entry))
+		}
+		return buf.String()
+	}
+
+	buf.WriteString("<details>\n")
+	fmt.Fprintf(&buf, "<summary>%s</summary>\n\n", commitCount(len(entries)))
+	for _, entry := range entries {
+		buf.WriteString(markdownEntry(entry))
+	}
+	buf.WriteString("\n</details>\n")
+
+	return buf.String()
+}
+
+func markdownEntry(entry summaryEntry) string {
+	var marker string
+	if entry.Marker != "" {
+		marker = entry.Marker + " "
+	}
+
+	subject := escapeMarkdown(entry.Before)
+	if entry.Match != "" {
+		subject += "**" + escapeMarkdown(entry.Match) + "**" + escapeMarkdown(entry.After)
+	}
+
+	if entry.Footer != "" {
+		subject += " (**" + escapeMarkdown(entry.Footer) + "**)"
+	}
+
+	if entry.Note != "" {
+		subject += " _" + escapeMarkdown(entry.Note) + "_"
+	}
+
+	return fmt.Sprintf("- %s%s %s\n", marker, markdownCode(entry.Hash), subject)
+}
+
+// markdownEscaper escapes any characters that would otherwise be treated as inline
+// formatting or html
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"~", `\~`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownCode wraps text within a code span, using a fence longer than any run of
+// backticks it contains. Backslashes cannot be used to escape within a code span
+func markdownCode(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	if longest > 0 {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
@@ -34,3 +34,58 @@ func PrintFormat(tag nsv.Tag, opts PlaygroundOptions) {
 
 	fmt.Fprint(opts.Out, pane)
 }
+
+// Scenario contains the outcome of simulating a release within the playground
+type Scenario struct {
+	// Simulation of the next semantic version
+	Simulation *nsv.Simulation
+
+	// TagMessage is the rendered message of an annotated tag
+	TagMessage string
+
+	// CommitMessage is the rendered message of any commit made after patching files
+	CommitMessage string
+}
+
+type ScenarioOptions struct {
+	NoColor bool
+	Out     io.Writer
+	Pretty  Pretty
+	Width   int
+}
+
+var scenarioLabel = lipgloss.NewStyle().Width(16).Bold(true)
+
+// PrintScenario prints how the next semantic version of a scenario would be
+// calculated, along with the messages rendered for its release
+func PrintScenario(scenario Scenario, opts ScenarioOptions) {
+	next := scenario.Simulation.Next
+	if next == nil {
+		fmt.Fprint(opts.Out, lipgloss.JoinVertical(lipgloss.Top,
+			scenarioRow("Increment", nsv.NoIncrement.String()),
+			"",
+			faint.Render("no release would be triggered by these commits"),
+		))
+		return
+	}
+
+	details := lipgloss.JoinVertical(lipgloss.Top,
+		scenarioRow("Increment", next.Increment.String()),
+		scenarioRow("Version", scenario.Simulation.Version.Raw),
+		scenarioRow("Tag", next.Tag),
+		scenarioRow("Tag Message", scenario.TagMessage),
+		scenarioRow("Commit Message", scenario.CommitMessage),
+	)
+
+	summary := TerminalRenderer{
+		NoColor: opts.NoColor,
+		Pretty:  opts.Pretty,
+		Width:   opts.Width,
+	}.Render([]*nsv.Next{next})
+
+	fmt.Fprint(opts.Out, lipgloss.JoinVertical(lipgloss.Top, details, summary))
+}
+
+func scenarioRow(label, value string) string {
+	return lipgloss.JoinHorizontal(lipgloss.Top, scenarioLabel.Render(label), value)
+}
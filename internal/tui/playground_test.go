@@ -0,0 +1,45 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintScenario(t *testing.T) {
+	t.Parallel()
+
+	ver, _ := nsv.ParseTag("0.2.0")
+	scenario := tui.Scenario{
+		Simulation: &nsv.Simulation{
+			Next: &nsv.Next{
+				Tag:       "v0.2.0",
+				PrevTag:   "v0.1.0",
+				LogDir:    ".",
+				Increment: nsv.MinorIncrement,
+				Log:       versions[1].Log,
+				Match:     versions[1].Match,
+			},
+			Version: ver,
+		},
+		TagMessage:    "chore: tagged release v0.2.0",
+		CommitMessage: "chore: patched files for release v0.2.0 [skip ci]",
+	}
+
+	var buf bytes.Buffer
+	tui.PrintScenario(scenario, tui.ScenarioOptions{Out: &buf, Pretty: tui.Oneline})
+
+	golden.Assert(t, buf.String(), "TestPrintScenario.golden")
+}
+
+func TestPrintScenarioNoIncrement(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tui.PrintScenario(tui.Scenario{Simulation: &nsv.Simulation{}}, tui.ScenarioOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintScenarioNoIncrement.golden")
+}
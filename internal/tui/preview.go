@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+const (
+	previewHeading   = "## Release Preview"
+	previewNoRelease = "Merging this pull request will not trigger a release."
+)
+
+type PreviewOptions struct {
+	Out io.Writer
+}
+
+// PrintPreview renders a markdown summary of the semantic versions that
+// would be released if a pull request was merged. It is designed to be
+// posted as a comment against the pull request
+func PrintPreview(vers []*nsv.Next, opts PreviewOptions) {
+	var buf strings.Builder
+	buf.WriteString(previewHeading + "\n\n")
+
+	if len(vers) == 0 {
+		buf.WriteString(previewNoRelease + "\n")
+		fmt.Fprint(opts.Out, buf.String())
+		return
+	}
+
+	buf.WriteString("| Path | Version | Previous | Increment |\n")
+	buf.WriteString("|------|---------|----------|-----------|\n")
+	for _, ver := range vers {
+		fmt.Fprintf(&buf, "| `%s` | `%s` | `%s` | %s |\n", ver.LogDir, ver.Tag, ver.PrevTag, ver.Increment)
+	}
+
+	for _, ver := range vers {
+		fmt.Fprintf(&buf, "\n### %s\n\n", ver.Tag)
+		for i, entry := range ver.Log {
+			buf.WriteString(previewEntry(entry.AbbrevHash, entry.Message, i == ver.Match.Index, ver.Match))
+		}
+	}
+
+	fmt.Fprint(opts.Out, buf.String())
+}
+
+func previewEntry(hash, msg string, matched bool, match nsv.Match) string {
+	subject, _, _ := strings.Cut(msg, "\n")
+	if !matched {
+		return fmt.Sprintf("- `%s` %s\n", hash, subject)
+	}
+
+	// Highlight the conventional prefix or footer that triggered the increment
+	before, highlighted, after, footer := splitMatch(msg, match)
+	if highlighted != "" {
+		subject = before + "**" + highlighted + "**" + after
+	} else if footer != "" {
+		subject = fmt.Sprintf("%s (**%s**)", subject, footer)
+	}
+
+	return fmt.Sprintf("- :white_check_mark: `%s` %s\n", hash, subject)
+}
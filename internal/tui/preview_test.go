@@ -0,0 +1,32 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintPreview(t *testing.T) {
+	t.Parallel()
+
+	previewVersions := copyVersions(t)
+	previewVersions[0].Increment = nsv.MinorIncrement
+	previewVersions[1].Increment = nsv.PatchIncrement
+
+	var buf bytes.Buffer
+	tui.PrintPreview(previewVersions, tui.PreviewOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintPreview.golden")
+}
+
+func TestPrintPreviewNoRelease(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tui.PrintPreview(nil, tui.PreviewOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintPreviewNoRelease.golden")
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+type Format string
+
+const (
+	Terminal Format = "terminal"
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+var Formats = []string{string(Terminal), string(Markdown), string(HTML)}
+
+const (
+	matchedMarker = "✓"
+	revertMarker  = "↺"
+	ignoreMarker  = "⊘"
+)
+
+// Renderer renders a summary of each semantic version, describing how it was
+// generated from the commit history
+type Renderer interface {
+	Render(vers []*nsv.Next) string
+}
+
+// NewRenderer returns the renderer for the chosen format. A summary is rendered
+// for the terminal if no format is chosen
+func NewRenderer(opts SummaryOptions) Renderer {
+	switch opts.Format {
+	case Markdown:
+		return MarkdownRenderer{Pretty: opts.Pretty}
+	case HTML:
+		return HTMLRenderer{Pretty: opts.Pretty}
+	default:
+		return TerminalRenderer{
+			MaxLines: opts.MaxLines,
+			NoColor:  opts.NoColor,
+			Pretty:   opts.Pretty,
+			Width:    opts.Width,
+		}
+	}
+}
+
+// summaryEntry describes a single commit within a summary, independent of
+// how it will be rendered
+type summaryEntry struct {
+	Hash    string
+	Marker  string
+	Matched bool
+	Note    string
+
+	// The subject of the commit is split around the span that triggered the
+	// increment. If the span sits within the body of the commit, such as a
+	// breaking change footer, it is held separately
+	Before string
+	Match  string
+	After  string
+	Footer string
+}
+
+// summaryEntries prepares the log of a semantic version for rendering. A compact
+// summary only contains the commit that triggered the increment, if there is one
+func summaryEntries(next *nsv.Next, pretty Pretty) []summaryEntry {
+	reverts, ignored := logNotes(next)
+	compact := pretty == Compact && next.Match.Index >= 0
+
+	entries := make([]summaryEntry, 0, len(next.Log))
+	for i, entry := range next.Log {
+		matched := i == next.Match.Index
+		if compact && !matched {
+			continue
+		}
+
+		subject, _, _ := strings.Cut(entry.Message, "\n")
+		e := summaryEntry{Hash: entry.AbbrevHash, Before: subject}
+
+		if matched {
+			e.Marker = matchedMarker
+			e.Matched = true
+			e.Before, e.Match, e.After, e.Footer = splitMatch(entry.Message, next.Match)
+		}
+
+		if pairing, reverted := reverts[i]; reverted {
+			e.Marker = revertMarker
+			e.Note = pairing
+		}
+
+		if reason, skipped := ignored[i]; skipped {
+			e.Marker = ignoreMarker
+			e.Note = reason
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+// splitMatch splits the subject of a commit around the span that triggered the
+// increment, the same span highlighted within the terminal
+func splitMatch(msg string, match nsv.Match) (string, string, string, string) {
+	subject, _, _ := strings.Cut(msg, "\n")
+	if match.End <= match.Start {
+		return subject, "", "", ""
+	}
+
+	if match.End <= len(subject) {
+		return subject[:match.Start], subject[match.Start:match.End], subject[match.End:], ""
+	}
+
+	return subject, "", "", strings.TrimSpace(msg[match.Start:match.End])
+}
+
+// logNotes describes why any commit within the log took no part in detecting the
+// increment, either through a revert or by being ignored
+func logNotes(next *nsv.Next) (map[int]string, map[int]string) {
+	reverts := map[int]string{}
+	for _, revert := range next.Reverts {
+		reverts[revert.Index] = "(reverts " + next.Log[revert.Reverted].AbbrevHash + ")"
+		reverts[revert.Reverted] = "(reverted by " + next.Log[revert.Index].AbbrevHash + ")"
+	}
+
+	ignored := map[int]string{}
+	for _, ignore := range next.Ignored {
+		ignored[ignore.Index] = "(ignored: " + ignore.Reason + ")"
+	}
+
+	return reverts, ignored
+}
+
+func patchedPaths(next *nsv.Next) []string {
+	paths := make([]string, 0, len(next.Diffs))
+	for _, diff := range next.Diffs {
+		paths = append(paths, diff.Path)
+	}
+	return paths
+}
+
+func commitCount(n int) string {
+	if n == 1 {
+		return "1 commit"
+	}
+	return strconv.Itoa(n) + " commits"
+}
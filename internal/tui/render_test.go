@@ -0,0 +1,97 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintSummaryMarkdown(t *testing.T) {
+	t.Parallel()
+
+	vers := copyVersions(t)
+	vers[0].TagType = "annotated"
+	vers[0].TagTarget = "patch"
+	vers[0].Diffs = []git.FileDiff{{Path: "src/ui/go.mod"}}
+	vers[1].Ignored = []nsv.Ignored{{Index: 1, Reason: "dependabot"}}
+
+	var buf bytes.Buffer
+	tui.PrintSummary(vers, tui.SummaryOptions{Out: &buf, Format: tui.Markdown})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryMarkdown.golden")
+}
+
+func TestPrintSummaryMarkdownCompact(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tui.PrintSummary(versions, tui.SummaryOptions{Out: &buf, Format: tui.Markdown, Pretty: tui.Compact})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryMarkdownCompact.golden")
+}
+
+func TestPrintSummaryMarkdownBreakingFooter(t *testing.T) {
+	t.Parallel()
+
+	msg := `refactor: restructure search indexes
+
+BREAKING CHANGE: indexes must be rebuilt`
+	next := &nsv.Next{
+		Tag:     "1.0.0",
+		PrevTag: "0.2.0",
+		LogDir:  ".",
+		Log: []git.LogEntry{
+			{AbbrevHash: "7d6e5f4", Message: `Revert "fix: rebuild indexes on startup"`},
+			{AbbrevHash: "5e4d3c2", Message: "fix: rebuild indexes on startup"},
+			{AbbrevHash: "3c2b1a0", Message: msg},
+		},
+		Match: nsv.Match{
+			Index: 2,
+			Start: 38,
+			End:   53,
+		},
+		Reverts: []nsv.Revert{{Index: 0, Reverted: 1}},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintSummary([]*nsv.Next{next}, tui.SummaryOptions{Out: &buf, Format: tui.Markdown})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryMarkdownBreakingFooter.golden")
+}
+
+func TestPrintSummaryMarkdownEscapes(t *testing.T) {
+	t.Parallel()
+
+	next := &nsv.Next{
+		Tag:       "0.2.0",
+		PrevTag:   "0.1.0",
+		LogDir:    "src/`ui`",
+		TagType:   "annotated",
+		TagTarget: "patch",
+		Log: []git.LogEntry{
+			{AbbrevHash: "0a1b2c3", Message: "feat: render <html> and *bold* text within `code` and snake_case"},
+		},
+		Match: nsv.Match{Index: 0, Start: 0, End: 4},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintSummary([]*nsv.Next{next}, tui.SummaryOptions{Out: &buf, Format: tui.Markdown})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryMarkdownEscapes.golden")
+}
+
+func TestPrintSummaryHTML(t *testing.T) {
+	t.Parallel()
+
+	vers := copyVersions(t)
+	vers[1].Log = append(vers[1].Log, git.LogEntry{AbbrevHash: "0a1b2c3", Message: "docs: escape <html> within commits & notes"})
+
+	var buf bytes.Buffer
+	tui.PrintSummary(vers, tui.SummaryOptions{Out: &buf, Format: tui.HTML})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryHTML.golden")
+}
@@ -0,0 +1,467 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	theme "github.com/purpleclay/lipgloss-theme"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+const (
+	defaultReviewHeight = 24
+	minLogRows          = 5
+	reviewChromeRows    = 14
+)
+
+// ErrReviewAborted is returned when a review is aborted, signalling that the entire
+// run should stop rather than skipping a single release
+var ErrReviewAborted = errors.New("release review aborted")
+
+// overrides cycled through when reviewing a release, where NoIncrement keeps
+// the detected increment
+var incrementOverrides = []nsv.Increment{
+	nsv.NoIncrement,
+	nsv.PatchIncrement,
+	nsv.MinorIncrement,
+	nsv.MajorIncrement,
+}
+
+var (
+	excludeMark = lipgloss.NewStyle().Foreground(
+		lipgloss.AdaptiveColor{
+			Light: string(theme.S400),
+			Dark:  string(theme.S200),
+		})
+	errorText   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	addedLine   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: string(theme.Green900), Dark: string(theme.Green700)})
+	removedLine = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+type ReviewOptions struct {
+	In  io.Reader
+	Out io.Writer
+
+	// Position and Total identify the release being reviewed, when more than
+	// one path is being released
+	Position int
+	Total    int
+
+	// Adjust recalculates the release after excluding commits or overriding
+	// the increment
+	Adjust func(adj nsv.Adjustment) (*nsv.Next, error)
+
+	// TagMessage renders the default message of an annotated tag. The message
+	// cannot be edited if not set, such as when creating a lightweight tag
+	TagMessage func(next *nsv.Next) string
+}
+
+// ReviewResult captures the outcome of reviewing a release. The release is only
+// confirmed if it should be tagged
+type ReviewResult struct {
+	Confirmed  bool
+	Next       *nsv.Next
+	TagMessage string
+}
+
+// IsTerminal reports whether both the input and output are attached to a terminal,
+// a requirement for reviewing a release interactively
+func IsTerminal(in io.Reader, out io.Writer) bool {
+	inf, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(inf.Fd()) {
+		return false
+	}
+
+	outf, ok := out.(*os.File)
+	return ok && term.IsTerminal(outf.Fd())
+}
+
+// Review interactively walks through a release before it is tagged. Commits can
+// be excluded, the increment overridden, and the tag message edited. Any files
+// patched by a hook can also be previewed
+func Review(next *nsv.Next, opts ReviewOptions) (ReviewResult, error) {
+	p := tea.NewProgram(newReviewModel(next, opts),
+		tea.WithInput(opts.In),
+		tea.WithOutput(opts.Out),
+	)
+
+	final, err := p.Run()
+	if err != nil {
+		return ReviewResult{}, err
+	}
+
+	m := final.(reviewModel)
+	if m.aborted {
+		return ReviewResult{}, ErrReviewAborted
+	}
+
+	return ReviewResult{
+		Confirmed:  m.confirmed && m.next != nil,
+		Next:       m.next,
+		TagMessage: m.message.Value(),
+	}, nil
+}
+
+type reviewMode int
+
+const (
+	reviewLog reviewMode = iota
+	reviewMessage
+	reviewDiff
+)
+
+type reviewModel struct {
+	opts      ReviewOptions
+	next      *nsv.Next
+	log       []string
+	excluded  map[int]bool
+	override  int
+	cursor    int
+	offset    int
+	height    int
+	mode      reviewMode
+	message   textarea.Model
+	edited    bool
+	diff      viewport.Model
+	err       error
+	confirmed bool
+	aborted   bool
+}
+
+func newReviewModel(next *nsv.Next, opts ReviewOptions) reviewModel {
+	msg := textarea.New()
+	msg.ShowLineNumbers = false
+	msg.SetHeight(3)
+	msg.SetWidth(logWrapAt)
+	if opts.TagMessage != nil {
+		msg.SetValue(opts.TagMessage(next))
+	}
+
+	m := reviewModel{
+		opts:     opts,
+		next:     next,
+		excluded: map[int]bool{},
+		height:   defaultReviewHeight,
+		message:  msg,
+		diff:     viewport.New(logWrapAt, defaultReviewHeight-4),
+	}
+
+	for _, entry := range next.Log {
+		m.log = append(m.log, entry.AbbrevHash+" "+firstLine(entry.Message))
+	}
+	m.diff.SetContent(renderDiffs(next))
+
+	return m
+}
+
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.height = size.Height
+		m.diff.Width = size.Width
+		m.diff.Height = max(size.Height-4, minLogRows)
+		m.message.SetWidth(min(size.Width-2, logWrapAt))
+		return m, nil
+	}
+
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if key.Type == tea.KeyCtrlC {
+		m.aborted = true
+		return m, tea.Quit
+	}
+
+	switch m.mode {
+	case reviewMessage:
+		return m.updateMessage(key)
+	case reviewDiff:
+		return m.updateDiff(key)
+	default:
+		return m.updateLog(key)
+	}
+}
+
+func (m reviewModel) updateLog(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.log)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		m.excluded[m.cursor] = !m.excluded[m.cursor]
+		m.adjust()
+	case "i":
+		m.override = (m.override + 1) % len(incrementOverrides)
+		m.adjust()
+	case "m":
+		if m.next != nil && m.opts.TagMessage != nil {
+			m.mode = reviewMessage
+			return m, m.message.Focus()
+		}
+	case "d":
+		if m.next != nil && len(m.next.Diffs) > 0 {
+			m.mode = reviewDiff
+		}
+	case "enter", "y":
+		if m.next != nil && m.next.Match.Index >= 0 && m.err == nil {
+			m.confirmed = true
+			return m, tea.Quit
+		}
+	case "q", "esc":
+		return m, tea.Quit
+	}
+
+	m.scroll()
+	return m, nil
+}
+
+func (m reviewModel) updateMessage(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Type == tea.KeyEsc {
+		m.message.Blur()
+		m.mode = reviewLog
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.message, cmd = m.message.Update(key)
+	m.edited = true
+	return m, cmd
+}
+
+func (m reviewModel) updateDiff(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "esc", "d", "q":
+		m.mode = reviewLog
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.diff, cmd = m.diff.Update(key)
+	return m, cmd
+}
+
+// adjust recalculates the release from the current set of exclusions and increment override
+func (m *reviewModel) adjust() {
+	var adj nsv.Adjustment
+	for idx, excluded := range m.excluded {
+		if excluded {
+			adj.Exclude = append(adj.Exclude, idx)
+		}
+	}
+	adj.Increment = incrementOverrides[m.override]
+
+	next, err := m.opts.Adjust(adj)
+	m.err = err
+	if err != nil {
+		return
+	}
+
+	m.next = next
+	if next == nil {
+		return
+	}
+
+	if !m.edited && m.opts.TagMessage != nil {
+		m.message.SetValue(m.opts.TagMessage(next))
+	}
+	m.diff.SetContent(renderDiffs(next))
+}
+
+// scroll keeps the cursor within the visible window of the log
+func (m *reviewModel) scroll() {
+	rows := m.logRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+func (m reviewModel) logRows() int {
+	return max(m.height-reviewChromeRows, minLogRows)
+}
+
+func (m reviewModel) View() string {
+	if m.mode == reviewDiff {
+		return lipgloss.JoinVertical(lipgloss.Top,
+			theme.U.Render("Patches"),
+			"",
+			m.diff.View(),
+			"",
+			faint.Render("↑/↓ scroll • esc back"),
+		)
+	}
+
+	sections := []string{m.viewHeader(), "", m.viewLog(), ""}
+
+	if m.next != nil && m.opts.TagMessage != nil {
+		sections = append(sections, theme.U.Render("Tag Message"), m.viewMessage(), "")
+	}
+
+	if m.err != nil {
+		sections = append(sections, errorText.Render(m.err.Error()), "")
+	}
+
+	sections = append(sections, m.viewHelp())
+	return lipgloss.JoinVertical(lipgloss.Top, sections...) + "\n"
+}
+
+func (m reviewModel) viewHeader() string {
+	var header string
+	if m.next == nil {
+		header = theme.H4.Render("nothing to release")
+	} else {
+		header = lipgloss.JoinHorizontal(lipgloss.Top,
+			theme.H1.Render(m.next.Tag),
+			diffMark.Render(),
+			" ",
+			theme.H4.Render(m.next.PrevTag),
+			" ",
+			faint.Render(fmt.Sprintf("(%s)", m.next.Increment.String())),
+		)
+	}
+
+	var info []string
+	if m.opts.Total > 1 {
+		info = append(info, fmt.Sprintf("%d/%d", m.opts.Position, m.opts.Total))
+	}
+
+	if incrementOverrides[m.override] != nsv.NoIncrement {
+		info = append(info, "forced "+incrementOverrides[m.override].String())
+	}
+
+	if m.next != nil && m.next.LogDir != "." {
+		info = append(info, "dir: "+m.next.LogDir)
+	}
+
+	if len(info) > 0 {
+		header = lipgloss.JoinHorizontal(lipgloss.Top, header, " ", faint.Render("("+strings.Join(info, ", ")+")"))
+	}
+	return header
+}
+
+func (m reviewModel) viewLog() string {
+	reasons := map[int]string{}
+	if m.next != nil {
+		for _, ignore := range m.next.Ignored {
+			if ignore.Reason != nsv.ExcludedReason {
+				reasons[ignore.Index] = ignoreMark.Render()
+			}
+		}
+
+		for _, revert := range m.next.Reverts {
+			reasons[revert.Index] = revertMark.Render()
+			reasons[revert.Reverted] = revertMark.Render()
+		}
+	}
+
+	end := min(m.offset+m.logRows(), len(m.log))
+	lines := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		check := "[ ]"
+		if m.excluded[i] {
+			check = excludeMark.Render("[x]")
+		}
+
+		marker := reasons[i]
+		if m.next != nil && i == m.next.Match.Index {
+			marker = theme.Tick
+		}
+
+		entry := m.log[i]
+		if m.excluded[i] {
+			entry = faint.Render(entry)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", cursor, check, entry, marker))
+	}
+
+	if hidden := len(m.log) - end; hidden > 0 {
+		lines = append(lines, faint.Render(fmt.Sprintf("  ... %d more", hidden)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m reviewModel) viewMessage() string {
+	if m.mode == reviewMessage {
+		return m.message.View()
+	}
+	return m.message.Value()
+}
+
+func (m reviewModel) viewHelp() string {
+	if m.mode == reviewMessage {
+		return faint.Render("esc done")
+	}
+
+	help := []string{"↑/↓ move", "space exclude", "i increment"}
+	if m.next != nil {
+		if m.opts.TagMessage != nil {
+			help = append(help, "m edit message")
+		}
+
+		if len(m.next.Diffs) > 0 {
+			help = append(help, "d patches")
+		}
+		help = append(help, "enter confirm")
+	}
+	help = append(help, "q skip", "ctrl+c abort")
+
+	return faint.Render(strings.Join(help, " • "))
+}
+
+func renderDiffs(next *nsv.Next) string {
+	var buf strings.Builder
+	for _, diff := range next.Diffs {
+		buf.WriteString(theme.Mark.Render(diff.Path) + "\n")
+		for _, chunk := range diff.Chunks {
+			fmt.Fprintf(&buf, "@@ -%d,%d +%d,%d @@\n",
+				chunk.Removed.LineNo, chunk.Removed.Count, chunk.Added.LineNo, chunk.Added.Count)
+
+			for _, line := range changeLines(chunk.Removed.Change) {
+				buf.WriteString(removedLine.Render("-"+line) + "\n")
+			}
+
+			for _, line := range changeLines(chunk.Added.Change) {
+				buf.WriteString(addedLine.Render("+"+line) + "\n")
+			}
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+func changeLines(change string) []string {
+	if change == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(change, "\n"), "\n")
+}
+
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+	return line
+}
@@ -0,0 +1,135 @@
+package tui_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewNext() *nsv.Next {
+	return &nsv.Next{
+		Tag:       "0.2.0",
+		PrevTag:   "0.1.0",
+		Increment: nsv.MinorIncrement,
+		LogDir:    ".",
+		Log: []git.LogEntry{
+			{AbbrevHash: "a1b2c3d", Message: "feat: support exporting traces to jaeger"},
+			{AbbrevHash: "b2c3d4e", Message: "fix: spans not closed on error"},
+		},
+	}
+}
+
+func tagMessage(next *nsv.Next) string {
+	return "chore: tagged release " + next.Tag
+}
+
+func TestReviewConfirm(t *testing.T) {
+	next := reviewNext()
+
+	result, err := tui.Review(next, tui.ReviewOptions{
+		In:         strings.NewReader("\r"),
+		Out:        io.Discard,
+		TagMessage: tagMessage,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Confirmed)
+	assert.Equal(t, next, result.Next)
+	assert.Equal(t, "chore: tagged release 0.2.0", result.TagMessage)
+}
+
+func TestReviewSkip(t *testing.T) {
+	result, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader("q"),
+		Out: io.Discard,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.Confirmed)
+}
+
+func TestReviewExcludeCommit(t *testing.T) {
+	var adjustments []nsv.Adjustment
+	adjusted := &nsv.Next{Tag: "0.1.1", PrevTag: "0.1.0", Increment: nsv.PatchIncrement}
+
+	result, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader(" \r"),
+		Out: io.Discard,
+		Adjust: func(adj nsv.Adjustment) (*nsv.Next, error) {
+			adjustments = append(adjustments, adj)
+			return adjusted, nil
+		},
+		TagMessage: tagMessage,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, adjustments, 1)
+	assert.Equal(t, []int{0}, adjustments[0].Exclude)
+	assert.Equal(t, nsv.NoIncrement, adjustments[0].Increment)
+
+	assert.True(t, result.Confirmed)
+	assert.Equal(t, adjusted, result.Next)
+	assert.Equal(t, "chore: tagged release 0.1.1", result.TagMessage)
+}
+
+func TestReviewOverrideIncrement(t *testing.T) {
+	var adjustments []nsv.Adjustment
+
+	_, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader("i\r"),
+		Out: io.Discard,
+		Adjust: func(adj nsv.Adjustment) (*nsv.Next, error) {
+			adjustments = append(adjustments, adj)
+			return reviewNext(), nil
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, adjustments, 1)
+	assert.Empty(t, adjustments[0].Exclude)
+	assert.Equal(t, nsv.PatchIncrement, adjustments[0].Increment)
+}
+
+func TestReviewCannotConfirmWithoutRelease(t *testing.T) {
+	result, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader(" \rq"),
+		Out: io.Discard,
+		Adjust: func(_ nsv.Adjustment) (*nsv.Next, error) {
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.Confirmed)
+	assert.Nil(t, result.Next)
+}
+
+func TestReviewCannotConfirmWithoutMatch(t *testing.T) {
+	unmatched := reviewNext()
+	unmatched.Match = nsv.NoMatch
+
+	result, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader(" \rq"),
+		Out: io.Discard,
+		Adjust: func(_ nsv.Adjustment) (*nsv.Next, error) {
+			return unmatched, nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.Confirmed)
+}
+
+func TestReviewAbort(t *testing.T) {
+	_, err := tui.Review(reviewNext(), tui.ReviewOptions{
+		In:  strings.NewReader("\x03"),
+		Out: io.Discard,
+	})
+	require.ErrorIs(t, err, tui.ErrReviewAborted)
+}
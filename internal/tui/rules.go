@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	theme "github.com/purpleclay/lipgloss-theme"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+const noScope = "-"
+
+type RulesOptions struct {
+	Out io.Writer
+}
+
+// PrintRules prints the table of effective rules, ordered by type, showing the
+// increment each conventional commit type and scope will trigger
+func PrintRules(rules nsv.Rules, opts RulesOptions) {
+	rows := [][]string{
+		{theme.U.Render("Type"), theme.U.Render("Scope"), theme.U.Render("Increment")},
+	}
+
+	for _, rule := range rules.Effective() {
+		scope := strings.ToLower(rule.Scope)
+		if scope == "" {
+			scope = faint.Render(noScope)
+		}
+
+		rows = append(rows, []string{
+			strings.ToLower(rule.Type),
+			scope,
+			rule.Increment.String(),
+		})
+	}
+
+	out := theme.NewTable(rows).
+		Border(theme.ThinBorder).
+		String()
+
+	fmt.Fprint(opts.Out, lipgloss.JoinVertical(
+		lipgloss.Top,
+		"",
+		out,
+	))
+}
@@ -0,0 +1,43 @@
+package tui_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/purpleclay/nsv/internal/nsv"
+	"github.com/purpleclay/nsv/internal/tui"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/golden"
+)
+
+func TestPrintRules(t *testing.T) {
+	t.Parallel()
+
+	rules := nsv.Rules{
+		{Type: "FIX", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Scope: "INTERNAL", Increment: nsv.PatchIncrement},
+		{Type: "FEAT", Increment: nsv.MinorIncrement},
+		{Type: "PERF", Scope: nsv.AnyScope, Increment: nsv.PatchIncrement},
+		{Type: "DOCS", Increment: nsv.NoIncrement},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintRules(rules, tui.RulesOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintRules.golden")
+}
+
+func TestPrintRulesOverridden(t *testing.T) {
+	t.Parallel()
+
+	rules, err := nsv.AngularRules(nsv.Options{
+		PatchPrefixes: []string{"feat"},
+		Rules:         []string{"fix=minor", "fix=none", "feat=patch"},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	tui.PrintRules(rules, tui.RulesOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintRulesOverridden.golden")
+}
@@ -3,11 +3,15 @@ package tui
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/list"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/reflow/truncate"
 	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
 	theme "github.com/purpleclay/lipgloss-theme"
 	"github.com/purpleclay/nsv/internal/nsv"
 )
@@ -17,15 +21,24 @@ type Pretty string
 const (
 	Full    Pretty = "full"
 	Compact Pretty = "compact"
+	Oneline Pretty = "oneline"
 )
 
-var PrettyFormats = []string{string(Full), string(Compact)}
+var PrettyFormats = []string{string(Full), string(Compact), string(Oneline)}
 
 const (
 	minTagCellWidth = 15
 	minLogCellWidth = 50
+	minLogWrapAt    = 20
 	logWrapAt       = 80
 	markerFmt       = ">>%s<<"
+	ellipsis        = "…"
+
+	// space taken by the table borders, the padding of each cell and the
+	// marker alongside each commit
+	tableChrome = 3
+	cellPadding = 2
+	markerWidth = 2
 )
 
 var (
@@ -45,6 +58,8 @@ var (
 
 	faint          = lipgloss.NewStyle().Faint(true)
 	bullet         = faint.SetString(">")
+	revertMark     = faint.SetString("↺")
+	ignoreMark     = faint.SetString("⊘")
 	padRight       = lipgloss.NewStyle().PaddingRight(1)
 	padTop         = lipgloss.NewStyle().PaddingTop(1)
 	listEnumerator = lipgloss.NewStyle().Foreground(
@@ -56,22 +71,59 @@ var (
 )
 
 type SummaryOptions struct {
-	NoColor bool
-	Out     io.Writer
-	Pretty  Pretty
+	Format   Format
+	MaxLines int
+	NoColor  bool
+	Out      io.Writer
+	Pretty   Pretty
+
+	// Width of the terminal the summary is written to. If unknown, the
+	// summary is wrapped at a fixed width
+	Width int
 }
 
+// TerminalWidth detects the width of the terminal a summary is written to. Zero is
+// returned if the summary is not written to a terminal
+func TerminalWidth(out io.Writer) int {
+	f, ok := out.(*os.File)
+	if !ok {
+		return 0
+	}
+
+	width, _, err := term.GetSize(f.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// PrintSummary renders a summary of each semantic version using the renderer
+// for the chosen format
 func PrintSummary(vers []*nsv.Next, opts SummaryOptions) {
+	fmt.Fprint(opts.Out, NewRenderer(opts).Render(vers))
+}
+
+// TerminalRenderer renders a summary as a table, styled for display within a terminal
+type TerminalRenderer struct {
+	// MaxLines truncates the message of each commit after a number of lines.
+	// Messages are never truncated if set to zero
+	MaxLines int
+	NoColor  bool
+	Pretty   Pretty
+
+	// Width of the terminal. The log of each semantic version is wrapped to
+	// fit, or at a fixed width if unknown
+	Width int
+}
+
+func (r TerminalRenderer) Render(vers []*nsv.Next) string {
+	logWidth, wrapAt := r.layout(vers)
+
 	var rows [][]string
 	for _, ver := range vers {
 		var patches string
 		if len(ver.Diffs) > 0 {
-			paths := make([]string, 0, len(ver.Diffs))
-			for _, diff := range ver.Diffs {
-				paths = append(paths, diff.Path)
-			}
-
-			a := list.New(paths).
+			a := list.New(patchedPaths(ver)).
 				Enumerator(list.Dash).
 				EnumeratorStyle(listEnumerator).
 				String()
@@ -82,19 +134,27 @@ func PrintSummary(vers []*nsv.Next, opts SummaryOptions) {
 			)
 		}
 
-		tagDiff := lipgloss.JoinVertical(lipgloss.Top,
+		tagLines := []string{
 			theme.H1.Render(ver.Tag),
 			diffMark.Render(),
 			theme.H4.Render(ver.PrevTag),
-			patches,
-		)
+		}
+
+		if ver.TagType != "" {
+			tagLines = append(tagLines, padTop.Render(faint.Render(fmt.Sprintf("%s\n→ %s", ver.TagType, ver.TagTarget))))
+		}
+		tagLines = append(tagLines, patches)
+
+		tagDiff := lipgloss.JoinVertical(lipgloss.Top, tagLines...)
 
 		var log string
-		switch Pretty(opts.Pretty) {
+		switch r.Pretty {
 		case Compact:
-			log = printCompactSummary(ver, opts)
+			log = r.printCompactSummary(ver, wrapAt)
+		case Oneline:
+			log = r.printOnelineSummary(ver, wrapAt)
 		default:
-			log = printFullSummary(ver, opts)
+			log = r.printFullSummary(ver, wrapAt)
 		}
 
 		var sum []string
@@ -109,17 +169,35 @@ func PrintSummary(vers []*nsv.Next, opts SummaryOptions) {
 
 	out := theme.NewTable(rows).
 		Border(theme.ThinBorder).
-		Widths(minTagCellWidth, minLogCellWidth).
+		Widths(minTagCellWidth, logWidth).
 		String()
 
-	fmt.Fprint(opts.Out, lipgloss.JoinVertical(
+	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		"",
 		out,
-	))
+	)
 }
 
-func printFullSummary(next *nsv.Next, opts SummaryOptions) string {
+// layout calculates the minimum width of the log column and where its commit messages
+// wrap, so that the table fills the width of the terminal
+func (r TerminalRenderer) layout(vers []*nsv.Next) (int, int) {
+	if r.Width <= 0 {
+		return minLogCellWidth, logWrapAt
+	}
+
+	tagWidth := minTagCellWidth
+	for _, ver := range vers {
+		tagWidth = max(tagWidth, lipgloss.Width(ver.Tag)+cellPadding, lipgloss.Width(ver.PrevTag)+cellPadding)
+	}
+
+	logWidth := max(r.Width-tagWidth-tableChrome, minLogWrapAt+cellPadding+markerWidth)
+	return min(logWidth, minLogCellWidth), logWidth - cellPadding - markerWidth
+}
+
+func (r TerminalRenderer) printFullSummary(next *nsv.Next, wrapAt int) string {
+	reverts, ignored := logNotes(next)
+
 	log := make([]string, 0, len(next.Log))
 	for i, entry := range next.Log {
 		msg := entry.Message
@@ -127,38 +205,42 @@ func printFullSummary(next *nsv.Next, opts SummaryOptions) string {
 		marker := bullet.Render()
 		if i == next.Match.Index {
 			marker = theme.Tick
+			msg = r.highlightMatch(msg, next.Match)
+		}
 
-			matched := msg[next.Match.Start:next.Match.End]
-			replace := matched
-			if opts.NoColor {
-				replace = fmt.Sprintf(markerFmt, replace)
-			}
-			msg = strings.Replace(msg, matched, highlight.Render(replace), 1)
+		lines := []string{
+			theme.Mark.Render(entry.AbbrevHash),
+			r.wrap(msg, wrapAt),
+		}
+
+		if pairing, reverted := reverts[i]; reverted {
+			marker = revertMark.Render()
+			lines = append(lines, faint.Render(pairing))
+		}
+
+		if reason, skipped := ignored[i]; skipped {
+			marker = ignoreMark.Render()
+			lines = append(lines, faint.Render(reason))
 		}
 
 		log = append(log, lipgloss.JoinHorizontal(
 			lipgloss.Left,
 			padRight.Render(marker),
-			lipgloss.JoinVertical(
-				lipgloss.Top,
-				theme.Mark.Render(entry.AbbrevHash),
-				wordwrap.String(msg, logWrapAt)),
+			lipgloss.JoinVertical(lipgloss.Top, lines...),
 		))
 	}
 
 	return strings.Join(log, "\n\n")
 }
 
-func printCompactSummary(next *nsv.Next, opts SummaryOptions) string {
-	entry := next.Log[next.Match.Index]
-	msg := entry.Message
-
-	matched := msg[next.Match.Start:next.Match.End]
-	replace := matched
-	if opts.NoColor {
-		replace = fmt.Sprintf(markerFmt, replace)
+func (r TerminalRenderer) printCompactSummary(next *nsv.Next, wrapAt int) string {
+	// Without a triggering commit, there is nothing to condense the log down to
+	if next.Match.Index < 0 {
+		return r.printFullSummary(next, wrapAt)
 	}
-	msg = strings.Replace(msg, matched, highlight.Render(replace), 1)
+
+	entry := next.Log[next.Match.Index]
+	msg := r.highlightMatch(entry.Message, next.Match)
 
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
@@ -166,6 +248,107 @@ func printCompactSummary(next *nsv.Next, opts SummaryOptions) string {
 		lipgloss.JoinVertical(
 			lipgloss.Top,
 			theme.Mark.Render(entry.AbbrevHash),
-			wordwrap.String(msg, logWrapAt)),
+			r.wrap(msg, wrapAt)),
 	)
 }
+
+// printOnelineSummary lists the subject of each commit on a single line, truncating
+// any that would otherwise wrap
+func (r TerminalRenderer) printOnelineSummary(next *nsv.Next, wrapAt int) string {
+	entries := summaryEntries(next, Oneline)
+
+	log := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		var marker string
+		switch entry.Marker {
+		case matchedMarker:
+			marker = theme.Tick
+		case revertMarker:
+			marker = revertMark.Render()
+		case ignoreMarker:
+			marker = ignoreMark.Render()
+		default:
+			marker = bullet.Render()
+		}
+
+		var note string
+		if entry.Note != "" {
+			note = " " + entry.Note
+		}
+
+		hash := theme.Mark.Render(entry.Hash)
+		avail := wrapAt - lipgloss.Width(hash) - lipgloss.Width(note) - 1
+		if entry.Match != "" {
+			// Without color, markers surround the highlighted span and take up space
+			avail -= lipgloss.Width(r.highlight(entry.Match)) - lipgloss.Width(entry.Match)
+		}
+		avail = max(avail, 1)
+		subject := entry.Before + entry.Match + entry.After
+		if entry.Footer != "" {
+			subject += " (" + entry.Footer + ")"
+		}
+
+		visible := subject
+		if lipgloss.Width(subject) > avail {
+			visible = strings.TrimSuffix(truncate.String(subject, uint(avail-1)), ellipsis)
+			subject = visible + ellipsis
+		}
+
+		// Only highlight the span that triggered the increment if it survived truncation
+		if entry.Match != "" && len(entry.Before)+len(entry.Match) <= len(visible) {
+			subject = entry.Before + r.highlight(entry.Match) + subject[len(entry.Before)+len(entry.Match):]
+		}
+
+		log = append(log, fmt.Sprintf("%s %s %s%s",
+			marker,
+			hash,
+			subject,
+			faint.Render(note)))
+	}
+
+	return strings.Join(log, "\n")
+}
+
+func (r TerminalRenderer) highlight(matched string) string {
+	if r.NoColor {
+		matched = fmt.Sprintf(markerFmt, matched)
+	}
+	return highlight.Render(matched)
+}
+
+// highlightMatch highlights the span of a commit message that triggered the increment.
+// A forced increment has no span, leaving the message untouched
+func (r TerminalRenderer) highlightMatch(msg string, match nsv.Match) string {
+	if match.End <= match.Start {
+		return msg
+	}
+
+	matched := msg[match.Start:match.End]
+	return strings.Replace(msg, matched, r.highlight(matched), 1)
+}
+
+// wrap wraps a commit message to fit within the log, truncating it after the
+// maximum number of lines
+func (r TerminalRenderer) wrap(msg string, wrapAt int) string {
+	// Words longer than the log, such as URLs, are broken to stop them overflowing
+	wrapped := wrap.String(wordwrap.String(msg, wrapAt), wrapAt)
+	if r.MaxLines <= 0 {
+		return wrapped
+	}
+
+	lines := strings.Split(wrapped, "\n")
+	if len(lines) <= r.MaxLines {
+		return wrapped
+	}
+
+	hidden := len(lines) - r.MaxLines
+	lines = append(lines[:r.MaxLines], faint.Render(fmt.Sprintf("%s %d more %s", ellipsis, hidden, plural(hidden, "line"))))
+	return strings.Join(lines, "\n")
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -135,3 +135,155 @@ func TestPrintSummaryCompact(t *testing.T) {
 
 	golden.Assert(t, buf.String(), "TestPrintSummaryCompact.golden")
 }
+
+func TestPrintSummaryWithReverts(t *testing.T) {
+	t.Parallel()
+
+	next := &nsv.Next{
+		Tag:     "0.2.1",
+		PrevTag: "0.2.0",
+		LogDir:  ".",
+		Log: []git.LogEntry{
+			{
+				Hash:       "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+				AbbrevHash: "3f1c2d4",
+				Message: `Revert "feat!: switch search to use graphql"
+
+This reverts commit 9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b.`,
+			},
+			{
+				Hash:       "9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+				AbbrevHash: "9b8a7c6",
+				Message:    "feat!: switch search to use graphql",
+			},
+			{
+				Hash:       "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+				AbbrevHash: "1a2b3c4",
+				Message:    "fix: search results are not sorted by relevance",
+			},
+		},
+		Match: nsv.Match{
+			Index: 2,
+			Start: 0,
+			End:   3,
+		},
+		Reverts: []nsv.Revert{{Index: 0, Reverted: 1}},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintSummary([]*nsv.Next{next}, tui.SummaryOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryWithReverts.golden")
+}
+
+func TestPrintSummaryWithIgnored(t *testing.T) {
+	t.Parallel()
+
+	next := &nsv.Next{
+		Tag:     "0.2.1",
+		PrevTag: "0.2.0",
+		LogDir:  ".",
+		Log: []git.LogEntry{
+			{
+				Hash:       "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+				AbbrevHash: "3f1c2d4",
+				Message:    "fixup! fix: search results are not sorted by relevance",
+			},
+			{
+				Hash:       "9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+				AbbrevHash: "9b8a7c6",
+				Message:    "fix(deps): update module github.com/charmbracelet/lipgloss to v1.1.0",
+			},
+			{
+				Hash:       "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+				AbbrevHash: "1a2b3c4",
+				Message:    "fix: search results are not sorted by relevance",
+			},
+		},
+		Match: nsv.Match{
+			Index: 2,
+			Start: 0,
+			End:   3,
+		},
+		Ignored: []nsv.Ignored{
+			{Index: 0, Reason: "fixup!"},
+			{Index: 1, Reason: "29139614+renovate[bot]@users.noreply.github.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	tui.PrintSummary([]*nsv.Next{next}, tui.SummaryOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryWithIgnored.golden")
+}
+
+func TestPrintSummaryWithTagType(t *testing.T) {
+	t.Parallel()
+
+	versionsWithTagType := copyVersions(t)
+	versionsWithTagType[0].TagType = "lightweight"
+	versionsWithTagType[0].TagTarget = "head"
+	versionsWithTagType[0].Diffs = []git.FileDiff{
+		{Path: "src/ui/go.mod"},
+	}
+	versionsWithTagType[1].TagType = "annotated"
+	versionsWithTagType[1].TagTarget = "patch"
+
+	var buf bytes.Buffer
+	tui.PrintSummary(versionsWithTagType, tui.SummaryOptions{Out: &buf})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryWithTagType.golden")
+}
+
+func TestPrintSummaryWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		width int
+	}{
+		{name: "Narrow", width: 60},
+		{name: "Wide", width: 140},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			tui.PrintSummary(versions, tui.SummaryOptions{Out: &buf, Width: tt.width})
+
+			golden.Assert(t, buf.String(), "TestPrintSummaryWidth"+tt.name+".golden")
+		})
+	}
+}
+
+func TestPrintSummaryMaxLines(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tui.PrintSummary(versions, tui.SummaryOptions{Out: &buf, MaxLines: 2})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryMaxLines.golden")
+}
+
+func TestPrintSummaryOneline(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tui.PrintSummary(versions, tui.SummaryOptions{Out: &buf, Pretty: tui.Oneline})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryOneline.golden")
+}
+
+func TestPrintSummaryOnelineTruncated(t *testing.T) {
+	t.Parallel()
+
+	vers := copyVersions(t)
+	vers[1].Ignored = []nsv.Ignored{{Index: 1, Reason: "dependabot"}}
+
+	var buf bytes.Buffer
+	tui.PrintSummary(vers, tui.SummaryOptions{Out: &buf, Pretty: tui.Oneline, NoColor: true, Width: 70})
+
+	golden.Assert(t, buf.String(), "TestPrintSummaryOnelineTruncated.golden")
+}
@@ -0,0 +1,163 @@
+// Package nsv calculates the next semantic version of a git repository from its
+// commit history, without shelling out to the nsv binary.
+//
+// Calculating a version never changes the repository. Tagging and committing are
+// kept separate, see [Release], so the engine can be embedded within other tools:
+//
+//	gitc, _ := git.NewClient()
+//	repo := nsv.NewGitRepository(gitc)
+//
+//	next, err := nsv.NextVersion(repo, nsv.WithPath("src/ui"))
+//	if err != nil || next == nil {
+//		return err
+//	}
+//
+//	return nsv.Release(repo, next, nsv.WithPush())
+package nsv
+
+import (
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+// Increment identifies the part of a semantic version to bump
+type Increment = nsv.Increment
+
+const (
+	NoIncrement    = nsv.NoIncrement
+	PatchIncrement = nsv.PatchIncrement
+	MinorIncrement = nsv.MinorIncrement
+	MajorIncrement = nsv.MajorIncrement
+)
+
+// Supported commit conventions for detecting the next increment
+const (
+	AngularConvention = nsv.AngularConvention
+	GitmojiConvention = nsv.GitmojiConvention
+	RegexConvention   = nsv.RegexConvention
+)
+
+// Supported strategies when the next tag already exists
+const (
+	CollisionFail = nsv.CollisionFail
+	CollisionSkip = nsv.CollisionSkip
+	CollisionBump = nsv.CollisionBump
+)
+
+type (
+	// Tag is a parsed semantic version tag, including any monorepo prefix
+	Tag = nsv.Tag
+
+	// Next contains the next semantic version and how it was calculated
+	Next = nsv.Next
+
+	// Match identifies the commit, and the part of its message, that triggered
+	// the next semantic version
+	Match = nsv.Match
+
+	// Command contains any nsv command detected within a commit footer
+	Command = nsv.Command
+
+	// ConventionalStrategy detects the next increment from conventional commits
+	ConventionalStrategy = nsv.ConventionalStrategy
+
+	// Rule maps a conventional commit type and optional scope to an increment
+	Rule = nsv.Rule
+
+	// Rules is a table of rules, where the most specific rule wins
+	Rules = nsv.Rules
+
+	// Repository provides access to all of the git operations needed to calculate
+	// and release the next semantic version
+	Repository = nsv.Repository
+
+	// WorkTree is a file system of the working tree that can be written to, needed
+	// when implementing your own [Repository]
+	WorkTree = nsv.WorkTree
+
+	// GitRepository is a [Repository] backed by the git binary
+	GitRepository = nsv.GitRepository
+
+	// MemoryRepository is an in-memory [Repository] with a linear history
+	MemoryRepository = nsv.MemoryRepository
+
+	// Logger records the decisions made when calculating the next semantic version.
+	// It is satisfied by a *log.Logger from github.com/charmbracelet/log
+	Logger = nsv.Logger
+)
+
+// NextVersion calculates the next semantic version of a repository. A nil version
+// is returned if no commits since the latest tag warrant a release. The repository
+// is never changed, with the exception of fixing a shallow clone when requested
+// through [WithFixShallow]
+func NextVersion(repo Repository, opts ...Option) (*Next, error) {
+	options := Options{
+		Convention: AngularConvention,
+		Logger:     nsv.NoopLogger{},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := checkOptions(options); err != nil {
+		return nil, err
+	}
+
+	return nsv.NextVersion(repo, options.engineOptions())
+}
+
+// NewGitRepository creates a [Repository] that shells out to git using the
+// provided client
+func NewGitRepository(gitc *git.Client) *GitRepository {
+	return nsv.NewGitRepository(gitc)
+}
+
+// NewMemoryRepository creates an empty in-memory [Repository]. Its history is
+// built by committing and tagging directly against it
+func NewMemoryRepository() *MemoryRepository {
+	return nsv.NewMemoryRepository()
+}
+
+// ParseTag parses a semantic version tag, with an optional v and monorepo prefix,
+// e.g. ui/v0.1.0
+func ParseTag(raw string) (Tag, error) {
+	return nsv.ParseTag(raw)
+}
+
+// DetectCommand scans a log for the first commit with an nsv command in its footer,
+// e.g. nsv: force~major
+func DetectCommand(log []git.LogEntry) (Command, Match) {
+	return nsv.DetectCommand(log)
+}
+
+// Angular returns a strategy for detecting increments from conventional commits,
+// based on the Angular commit message format
+func Angular() ConventionalStrategy {
+	return nsv.Angular()
+}
+
+// ParseRules parses rules in the format type[(scope)]=increment, where the
+// increment can be one of either major, minor, patch or none
+func ParseRules(rules []string) (Rules, error) {
+	return nsv.ParseRules(rules)
+}
+
+func checkOptions(opts Options) error {
+	if err := nsv.CheckTemplate(opts.VersionFormat); err != nil {
+		return err
+	}
+
+	if err := nsv.CheckVersion("initial version", opts.InitialVersion); err != nil {
+		return err
+	}
+
+	if err := nsv.CheckVersion("min version", opts.MinVersion); err != nil {
+		return err
+	}
+
+	if opts.OnCollision != "" {
+		return nsv.CheckCollisionStrategy(opts.OnCollision)
+	}
+	return nil
+}
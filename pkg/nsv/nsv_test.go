@@ -0,0 +1,134 @@
+package nsv_test
+
+import (
+	"testing"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/gitz/gittest"
+	"github.com/purpleclay/nsv/pkg/nsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersion(t *testing.T) {
+	log := `(main, origin/main) feat(ui): support dark mode
+(tag: ui/v0.1.0) feat(ui): initial search ui`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.WithRules("feat(ui)=patch"))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	assert.Equal(t, "ui/v0.1.1", next.Tag)
+	assert.Equal(t, "ui/v0.1.0", next.PrevTag)
+	assert.Equal(t, nsv.PatchIncrement, next.Increment)
+}
+
+func TestNextVersionCustomOption(t *testing.T) {
+	log := `(main, origin/main) fix(ui): dark mode toggle not persisted
+(tag: ui/v0.1.0) feat(ui): initial search ui`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	stable := func(opts *nsv.Options) {
+		opts.MinVersion = "1.0.0"
+	}
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc), stable)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "ui/v1.0.0", next.Tag)
+}
+
+func TestNextVersionNoRelease(t *testing.T) {
+	log := `(main, origin/main) docs: document dark mode
+(tag: 0.1.0) feat: initial search ui`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc))
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func TestNextVersionInvalidOption(t *testing.T) {
+	gittest.InitRepository(t)
+	gitc, _ := git.NewClient()
+
+	_, err := nsv.NextVersion(nsv.NewGitRepository(gitc), nsv.WithOnCollision("replace"))
+	require.EqualError(t, err, "collision strategy 'replace' is not supported, must be one of either: fail, skip, bump")
+}
+
+func TestParseTagFormat(t *testing.T) {
+	tag, err := nsv.ParseTag("ui/v1.2.3-beta.1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ui", tag.Prefix)
+	assert.Equal(t, "1.2.3-beta.1", tag.SemVer)
+	assert.Equal(t, "beta.1", tag.Pre)
+	assert.Equal(t, "ui@1.2.3-beta.1", tag.Format("{{.Prefix}}@{{.SemVer}}"))
+}
+
+func TestRelease(t *testing.T) {
+	log := `(main, origin/main) feat: support dark mode
+(tag: 0.1.0) feat: initial search ui`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.NotContains(t, gittest.Tags(t), "0.2.0", "calculating a version should not tag the repository")
+
+	err = nsv.Release(nsv.NewGitRepository(gitc), next, nsv.WithTagMessage("release {{.Tag}} from {{.PrevTag}}"), nsv.WithPush())
+	require.NoError(t, err)
+
+	assert.Contains(t, gittest.RemoteTags(t), "0.2.0")
+	annotation := gittest.MustExec(t, "git tag -l 0.2.0 --format='%(contents:subject)'")
+	assert.Equal(t, "release 0.2.0 from 0.1.0", annotation)
+}
+
+func TestReleaseLightweightTag(t *testing.T) {
+	log := `(main, origin/main) fix: dark mode toggle not persisted
+(tag: 0.1.0) feat: initial search ui`
+	gittest.InitRepository(t, gittest.WithLog(log))
+	gitc, _ := git.NewClient()
+
+	next, err := nsv.NextVersion(nsv.NewGitRepository(gitc))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+
+	require.NoError(t, nsv.Release(nsv.NewGitRepository(gitc), next, nsv.WithLightweightTag()))
+
+	assert.Contains(t, gittest.Tags(t), "0.1.1")
+	assert.NotContains(t, gittest.RemoteTags(t), "0.1.1")
+	assert.Equal(t, "commit", gittest.MustExec(t, "git cat-file -t 0.1.1"))
+}
+
+func TestDetectCommand(t *testing.T) {
+	cmd, match := nsv.DetectCommand([]git.LogEntry{
+		{Message: "fix: dark mode toggle not persisted\nnsv: set~1.0.0"},
+	})
+
+	assert.Equal(t, "1.0.0", cmd.Set)
+	assert.Equal(t, 0, match.Index)
+}
+
+func TestReleaseMemoryRepository(t *testing.T) {
+	repo := nsv.NewMemoryRepository()
+	repo.Commit("feat: initial search ui", "index.ts")
+	require.NoError(t, repo.Tag("v0.1.0", "", ""))
+	repo.Commit("feat: support dark mode", "theme.ts")
+
+	next, err := nsv.NextVersion(repo)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "v0.2.0", next.Tag)
+
+	require.NoError(t, nsv.Release(repo, next, nsv.WithPush()))
+	assert.Equal(t, []string{"v0.2.0"}, repo.Pushed())
+
+	tags, _ := repo.RemoteTags()
+	assert.Contains(t, tags, "v0.2.0")
+}
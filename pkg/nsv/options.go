@@ -0,0 +1,208 @@
+package nsv
+
+import "github.com/purpleclay/nsv/internal/nsv"
+
+// Options contains everything that customizes how the next semantic version is
+// calculated. Each field is set through an [Option]
+type Options struct {
+	BaseRef          string
+	Convention       string
+	FixShallow       bool
+	IgnoreAuthors    []string
+	IgnoreCommits    []string
+	InitialVersion   string
+	Logger           Logger
+	MajorPattern     string
+	MajorPrefixes    []string
+	MinorPattern     string
+	MinorPrefixes    []string
+	MinVersion       string
+	NoDefaultIgnores bool
+	OnCollision      string
+	ParseBody        bool
+	PatchPattern     string
+	PatchPrefixes    []string
+	Path             string
+	Ref              string
+	Rules            []string
+	Since            string
+	VersionFormat    string
+}
+
+// Option customizes how the next semantic version is calculated
+type Option func(*Options)
+
+// engineOptions maps the options onto those used by the version engine
+func (o Options) engineOptions() nsv.Options {
+	return nsv.Options{
+		BaseRef:          o.BaseRef,
+		Convention:       o.Convention,
+		FixShallow:       o.FixShallow,
+		IgnoreAuthors:    o.IgnoreAuthors,
+		IgnoreCommits:    o.IgnoreCommits,
+		InitialVersion:   o.InitialVersion,
+		Logger:           o.Logger,
+		MajorPattern:     o.MajorPattern,
+		MajorPrefixes:    o.MajorPrefixes,
+		MinorPattern:     o.MinorPattern,
+		MinorPrefixes:    o.MinorPrefixes,
+		MinVersion:       o.MinVersion,
+		NoDefaultIgnores: o.NoDefaultIgnores,
+		OnCollision:      o.OnCollision,
+		ParseBody:        o.ParseBody,
+		PatchPattern:     o.PatchPattern,
+		PatchPrefixes:    o.PatchPrefixes,
+		Path:             o.Path,
+		Ref:              o.Ref,
+		Rules:            o.Rules,
+		Since:            o.Since,
+		VersionFormat:    o.VersionFormat,
+	}
+}
+
+// WithLogger records each decision made when calculating the next semantic
+// version. By default, all log output is discarded
+func WithLogger(logger Logger) Option {
+	return func(opts *Options) {
+		if logger != nil {
+			opts.Logger = logger
+		}
+	}
+}
+
+// WithPath calculates the next semantic version of a path within a monorepo,
+// relative to the root of the repository
+func WithPath(path string) Option {
+	return func(opts *Options) {
+		opts.Path = path
+	}
+}
+
+// WithFormat changes the format of the next semantic version using a go template,
+// e.g. ui/{{.Version}}
+func WithFormat(format string) Option {
+	return func(opts *Options) {
+		opts.VersionFormat = format
+	}
+}
+
+// WithConvention changes the commit convention used to detect the next increment,
+// which can be one of either angular, gitmoji or regex
+func WithConvention(convention string) Option {
+	return func(opts *Options) {
+		opts.Convention = convention
+	}
+}
+
+// WithPrefixes extends the conventional commit prefixes that trigger each increment
+func WithPrefixes(major, minor, patch []string) Option {
+	return func(opts *Options) {
+		opts.MajorPrefixes = major
+		opts.MinorPrefixes = minor
+		opts.PatchPrefixes = patch
+	}
+}
+
+// WithPatterns sets the regular expressions that trigger each increment. Must be
+// used with the regex convention
+func WithPatterns(major, minor, patch string) Option {
+	return func(opts *Options) {
+		opts.MajorPattern = major
+		opts.MinorPattern = minor
+		opts.PatchPattern = patch
+	}
+}
+
+// WithRules maps a conventional commit type and optional scope to an increment,
+// using the format type[(scope)]=increment. Rules take precedence over prefixes
+func WithRules(rules ...string) Option {
+	return func(opts *Options) {
+		opts.Rules = rules
+	}
+}
+
+// WithParseBody parses bullet-listed conventional commits within the body of
+// squash and merge commits when detecting the increment
+func WithParseBody() Option {
+	return func(opts *Options) {
+		opts.ParseBody = true
+	}
+}
+
+// WithIgnoreCommits ignores any commit with a message matching a regular expression
+func WithIgnoreCommits(patterns ...string) Option {
+	return func(opts *Options) {
+		opts.IgnoreCommits = patterns
+	}
+}
+
+// WithIgnoreAuthors ignores any commit with an author email matching a regular
+// expression
+func WithIgnoreAuthors(patterns ...string) Option {
+	return func(opts *Options) {
+		opts.IgnoreAuthors = patterns
+	}
+}
+
+// WithoutDefaultIgnores disables the built-in rules for ignoring fixup!, squash!,
+// amend!, merge branch, WIP and dependency bot commits
+func WithoutDefaultIgnores() Option {
+	return func(opts *Options) {
+		opts.NoDefaultIgnores = true
+	}
+}
+
+// WithInitialVersion sets the version to release when no previous tag exists
+func WithInitialVersion(version string) Option {
+	return func(opts *Options) {
+		opts.InitialVersion = version
+	}
+}
+
+// WithMinVersion raises the next semantic version to a minimum version, if it
+// would otherwise fall below it
+func WithMinVersion(version string) Option {
+	return func(opts *Options) {
+		opts.MinVersion = version
+	}
+}
+
+// WithOnCollision checks if the next tag already exists, both locally and on the
+// remote, applying a strategy of either fail, skip or bump
+func WithOnCollision(strategy string) Option {
+	return func(opts *Options) {
+		opts.OnCollision = strategy
+	}
+}
+
+// WithRef calculates the next semantic version at a given commit-ish, rather
+// than HEAD. Only tags reachable from it are considered
+func WithRef(ref string) Option {
+	return func(opts *Options) {
+		opts.Ref = ref
+	}
+}
+
+// WithSince calculates the next semantic version from a given tag, rather than
+// the latest tag
+func WithSince(tag string) Option {
+	return func(opts *Options) {
+		opts.Since = tag
+	}
+}
+
+// WithBaseRef narrows the log to commits between HEAD and a base ref, such as
+// those within a pull request
+func WithBaseRef(ref string) Option {
+	return func(opts *Options) {
+		opts.BaseRef = ref
+	}
+}
+
+// WithFixShallow fixes a shallow clone of a repository if detected, by fetching
+// its history and tags
+func WithFixShallow() Option {
+	return func(opts *Options) {
+		opts.FixShallow = true
+	}
+}
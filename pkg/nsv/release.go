@@ -0,0 +1,138 @@
+package nsv
+
+import (
+	"bytes"
+	"text/template"
+
+	git "github.com/purpleclay/gitz"
+	"github.com/purpleclay/nsv/internal/nsv"
+)
+
+const (
+	defaultTagMessage    = "chore: tagged release {{.Tag}}"
+	defaultCommitMessage = "chore: patched files for release {{.Tag}}"
+)
+
+// ReleaseOption customizes how a release is committed and tagged
+type ReleaseOption func(*releaseOptions)
+
+type releaseOptions struct {
+	commitMessage string
+	lightweight   bool
+	logger        Logger
+	push          bool
+	tagMessage    string
+}
+
+// WithCommitMessage sets the message used when committing any patched files. It
+// supports go templates, with access to both {{.Tag}} and {{.PrevTag}}
+func WithCommitMessage(msg string) ReleaseOption {
+	return func(opts *releaseOptions) {
+		opts.commitMessage = msg
+	}
+}
+
+// WithTagMessage sets the message of an annotated tag. It supports go templates,
+// with access to both {{.Tag}} and {{.PrevTag}}
+func WithTagMessage(msg string) ReleaseOption {
+	return func(opts *releaseOptions) {
+		opts.tagMessage = msg
+	}
+}
+
+// WithLightweightTag creates a lightweight tag rather than an annotated one
+func WithLightweightTag() ReleaseOption {
+	return func(opts *releaseOptions) {
+		opts.lightweight = true
+	}
+}
+
+// WithPush pushes the tag, and any commit of patched files, to the remote
+func WithPush() ReleaseOption {
+	return func(opts *releaseOptions) {
+		opts.push = true
+	}
+}
+
+// WithReleaseLogger records each step of a release. By default, all log output
+// is discarded
+func WithReleaseLogger(logger Logger) ReleaseOption {
+	return func(opts *releaseOptions) {
+		if logger != nil {
+			opts.logger = logger
+		}
+	}
+}
+
+// Release tags the repository with the next semantic version. Any files patched
+// while calculating the version are committed first, with the tag pointing to
+// that commit. Otherwise the tag points to the latest commit within the log
+func Release(repo Repository, next *Next, opts ...ReleaseOption) error {
+	options := releaseOptions{
+		commitMessage: defaultCommitMessage,
+		logger:        nsv.NoopLogger{},
+		tagMessage:    defaultTagMessage,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	hash := next.Log[0].Hash
+	refs := []string{next.Tag}
+
+	if len(next.Diffs) > 0 {
+		paths := make([]string, 0, len(next.Diffs))
+		for _, diff := range next.Diffs {
+			paths = append(paths, diff.Path)
+		}
+
+		msg, err := renderMessage(options.commitMessage, next)
+		if err != nil {
+			return err
+		}
+
+		if hash, err = repo.Commit(msg, paths...); err != nil {
+			return err
+		}
+		options.logger.Info("committed patched files", "commit", msg, "hash", hash)
+		refs = append([]string{git.HeadRef}, refs...)
+	}
+
+	var annotation string
+	if !options.lightweight {
+		var err error
+		if annotation, err = renderMessage(options.tagMessage, next); err != nil {
+			return err
+		}
+	}
+
+	if err := repo.Tag(next.Tag, hash, annotation); err != nil {
+		return err
+	}
+	options.logger.Info("tagged release", "tag", next.Tag, "hash", hash)
+
+	if !options.push {
+		return nil
+	}
+
+	if err := repo.Push(refs...); err != nil {
+		return err
+	}
+	options.logger.Info("pushed release to remote", "ref_specs", refs)
+	return nil
+}
+
+func renderMessage(msg string, next *Next) (string, error) {
+	tmpl, err := template.New("release-message").Parse(msg)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Tag     string
+		PrevTag string
+	}{Tag: next.Tag, PrevTag: next.PrevTag})
+	return buf.String(), err
+}